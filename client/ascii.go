@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Cell types mirror the server's CellType enum.
+const (
+	cellWall = iota
+	cellPath
+	cellStart
+	cellGoal
+)
+
+// MazeGridResponse is the full static maze layout fetched from /maze-grid,
+// used to render walls and start/goal markers that the per-exploration
+// endpoints don't expose.
+type MazeGridResponse struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Start  Position `json:"start"`
+	Goal   Position `json:"goal"`
+	Cells  [][]int  `json:"cells"`
+}
+
+const ansiReset = "\x1b[0m"
+
+// explorationColors is a palette of bright ANSI foreground codes cycled
+// through by hashing the exploration ID, so the same exploration keeps the
+// same color across frames of `watch`.
+var explorationColors = []string{
+	"\x1b[31m", "\x1b[32m", "\x1b[33m", "\x1b[34m", "\x1b[35m", "\x1b[36m",
+	"\x1b[91m", "\x1b[92m", "\x1b[93m", "\x1b[94m", "\x1b[95m", "\x1b[96m",
+}
+
+func explorationColor(id string) string {
+	h := 0
+	for _, c := range id {
+		h = h*31 + int(c)
+	}
+	if h < 0 {
+		h = -h
+	}
+	return explorationColors[h%len(explorationColors)]
+}
+
+// fetchMazeGrid retrieves the static maze layout. It only needs to be
+// fetched once per process since the grid itself never changes mid-run.
+func fetchMazeGrid(ctx context.Context) (MazeGridResponse, error) {
+	statusCode, respBody, err := client.get(ctx, gameURL("maze-grid"))
+	if err != nil {
+		return MazeGridResponse{}, err
+	}
+	if statusCode != 200 {
+		return MazeGridResponse{}, fmt.Errorf("server error: status %d", statusCode)
+	}
+	var grid MazeGridResponse
+	if err := json.Unmarshal(respBody, &grid); err != nil {
+		return MazeGridResponse{}, err
+	}
+	return grid, nil
+}
+
+func fetchExplorationTreeForAscii(ctx context.Context) (ExplorationTreeResponse, error) {
+	statusCode, respBody, err := client.get(ctx, gameURL("exploration-tree"))
+	if err != nil {
+		return ExplorationTreeResponse{}, err
+	}
+	if statusCode != 200 {
+		return ExplorationTreeResponse{}, fmt.Errorf("server error: status %d", statusCode)
+	}
+	var tree ExplorationTreeResponse
+	if err := json.Unmarshal(respBody, &tree); err != nil {
+		return ExplorationTreeResponse{}, err
+	}
+	return tree, nil
+}
+
+// renderAsciiOnce fetches the grid and tree once and prints a single frame.
+func renderAsciiOnce(ctx context.Context) {
+	grid, err := fetchMazeGrid(ctx)
+	if err != nil {
+		fmt.Printf("❌ Error fetching maze grid: %v\n", err)
+		return
+	}
+	tree, err := fetchExplorationTreeForAscii(ctx)
+	if err != nil {
+		fmt.Printf("❌ Error fetching exploration tree: %v\n", err)
+		return
+	}
+	fmt.Print(renderAsciiFrame(grid, tree))
+}
+
+// handleWatchArgs parses `watch [--interval N]` (N in milliseconds) and
+// dispatches to handleWatchCommand.
+func handleWatchArgs(args []string) {
+	intervalMs := 500
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--interval" {
+			fmt.Printf("❌ Unknown flag '%s'\n", args[i])
+			return
+		}
+		if i+1 >= len(args) {
+			fmt.Println("❌ --interval requires a value in milliseconds")
+			return
+		}
+		i++
+		n, err := strconv.Atoi(args[i])
+		if err != nil || n <= 0 {
+			fmt.Println("❌ --interval must be a positive integer")
+			return
+		}
+		intervalMs = n
+	}
+
+	handleWatchCommand(context.Background(), intervalMs)
+}
+
+// handleWatchCommand polls /exploration-tree every intervalMs, repainting the
+// terminal in place with ANSI cursor controls whenever the frame changes.
+// The maze grid itself is static, so it's fetched only once up front.
+func handleWatchCommand(ctx context.Context, intervalMs int) {
+	grid, err := fetchMazeGrid(ctx)
+	if err != nil {
+		fmt.Printf("❌ Error fetching maze grid: %v\n", err)
+		return
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT)
+	defer signal.Stop(sigCh)
+
+	fmt.Println("👀 Watching exploration tree (Ctrl-C to stop)...")
+
+	ticker := time.NewTicker(time.Duration(intervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	var prevFrame string
+	linesDrawn := 0
+
+	for {
+		select {
+		case <-sigCh:
+			fmt.Println("\n⏹️  Stopped watching")
+			return
+		case <-ticker.C:
+			tree, err := fetchExplorationTreeForAscii(ctx)
+			if err != nil {
+				continue
+			}
+			frame := renderAsciiFrame(grid, tree)
+			if frame == prevFrame {
+				continue
+			}
+			if linesDrawn > 0 {
+				fmt.Printf("\x1b[%dA\x1b[0J", linesDrawn)
+			}
+			fmt.Print(frame)
+			linesDrawn = strings.Count(frame, "\n")
+			prevFrame = frame
+		}
+	}
+}
+
+// renderAsciiFrame draws the maze grid using box-drawing characters for
+// walls, one ANSI color per exploration path, S/G markers for start/goal,
+// and a trailing progress bar of visited vs. estimated (walkable) cells.
+func renderAsciiFrame(grid MazeGridResponse, tree ExplorationTreeResponse) string {
+	occupied := make(map[Position]string, tree.GlobalStats.VisitedPositions)
+	for id, exp := range tree.Explorations {
+		col := explorationColor(id)
+		for _, pos := range exp.PathPositions {
+			occupied[pos] = col
+		}
+	}
+
+	walkable := 0
+	var b strings.Builder
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			cellType := grid.Cells[y][x]
+			if cellType == cellWall {
+				b.WriteString(wallGlyph(grid, x, y))
+				continue
+			}
+			walkable++
+
+			pos := Position{x, y}
+			switch {
+			case pos == grid.Start:
+				b.WriteString("\x1b[32mS" + ansiReset)
+			case pos == grid.Goal:
+				b.WriteString("\x1b[31mG" + ansiReset)
+			default:
+				if col, ok := occupied[pos]; ok {
+					b.WriteString(col + "●" + ansiReset)
+				} else if isJunctionCell(grid, x, y) {
+					b.WriteString("*")
+				} else {
+					b.WriteString(" ")
+				}
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(progressBar(tree.GlobalStats.VisitedPositions, walkable))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// wallGlyph picks a box-drawing character for a wall cell based on which of
+// its four neighbors are also walls, so walls read as connected corridors
+// rather than a uniform block.
+func wallGlyph(grid MazeGridResponse, x, y int) string {
+	isWall := func(nx, ny int) bool {
+		return nx >= 0 && nx < grid.Width && ny >= 0 && ny < grid.Height && grid.Cells[ny][nx] == cellWall
+	}
+	up, down := isWall(x, y-1), isWall(x, y+1)
+	left, right := isWall(x-1, y), isWall(x+1, y)
+
+	switch {
+	case up && down && left && right:
+		return "┼"
+	case up && down && left:
+		return "┤"
+	case up && down && right:
+		return "├"
+	case left && right && up:
+		return "┴"
+	case left && right && down:
+		return "┬"
+	case up && down:
+		return "│"
+	case left && right:
+		return "─"
+	case up && right:
+		return "└"
+	case up && left:
+		return "┘"
+	case down && right:
+		return "┌"
+	case down && left:
+		return "┐"
+	case up:
+		return "╵"
+	case down:
+		return "╷"
+	case left:
+		return "╴"
+	case right:
+		return "╶"
+	default:
+		return "█"
+	}
+}
+
+// isJunctionCell reports whether a walkable cell has more than two walkable
+// neighbors, i.e. it's a structural branch point in the maze.
+func isJunctionCell(grid MazeGridResponse, x, y int) bool {
+	walkable := func(nx, ny int) bool {
+		return nx >= 0 && nx < grid.Width && ny >= 0 && ny < grid.Height && grid.Cells[ny][nx] != cellWall
+	}
+	count := 0
+	for _, d := range []Direction{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		if walkable(x+d.X, y+d.Y) {
+			count++
+		}
+	}
+	return count > 2
+}
+
+// progressBar renders "visited / estimated maze size" as a bar so long-running
+// auto-solves give visible feedback instead of silent waiting.
+func progressBar(visited, total int) string {
+	const width = 40
+	if total <= 0 {
+		total = 1
+	}
+	filled := visited * width / total
+	if filled > width {
+		filled = width
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("[%s] %d/%d visited", bar, visited, total)
+}
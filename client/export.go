@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// handleExportPathArgs parses `export-path <exploration_name> --format {json,csv,svg}`
+// and dispatches to handleExportPathCommand.
+func handleExportPathArgs(args []string) {
+	if len(args) < 1 {
+		fmt.Println("❌ Usage: maze_client export-path <exploration_name> --format {json,csv,svg}")
+		return
+	}
+
+	name := args[0]
+	format := "json"
+
+	rest := args[1:]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] != "--format" {
+			fmt.Printf("❌ Unknown flag '%s'\n", rest[i])
+			return
+		}
+		if i+1 >= len(rest) {
+			fmt.Println("❌ --format requires a value")
+			return
+		}
+		i++
+		switch rest[i] {
+		case "json", "csv", "svg":
+			format = rest[i]
+		default:
+			fmt.Printf("❌ Unknown format '%s' (expected json, csv, or svg)\n", rest[i])
+			return
+		}
+	}
+
+	handleExportPathCommand(context.Background(), name, format)
+}
+
+// handleExportPathCommand walks PathPositions for name from the tree
+// response and writes it as coordinates (csv), a GeoJSON-like structure
+// (json), or an overlay SVG (svg) suitable for layering on the PNG render.
+func handleExportPathCommand(ctx context.Context, name, format string) {
+	tree, err := fetchExplorationTreeForAscii(ctx)
+	if err != nil {
+		fmt.Printf("❌ Error fetching exploration tree: %v\n", err)
+		return
+	}
+
+	exp, ok := tree.Explorations[name]
+	if !ok {
+		fmt.Printf("❌ Exploration '%s' not found\n", name)
+		return
+	}
+
+	filename := fmt.Sprintf("%s.%s", name, format)
+
+	var writeErr error
+	switch format {
+	case "csv":
+		writeErr = writePathCSV(filename, exp.PathPositions)
+	case "json":
+		writeErr = writePathJSON(filename, name, exp.PathPositions)
+	case "svg":
+		grid, err := fetchMazeGrid(ctx)
+		if err != nil {
+			fmt.Printf("❌ Error fetching maze grid: %v\n", err)
+			return
+		}
+		writeErr = writePathSVG(filename, grid, exp.PathPositions)
+	}
+
+	if writeErr != nil {
+		fmt.Printf("❌ Error writing %s: %v\n", filename, writeErr)
+		return
+	}
+
+	fmt.Printf("✅ Exported path for '%s' to %s (%d points)\n", name, filename, len(exp.PathPositions))
+}
+
+func writePathCSV(filename string, positions []Position) error {
+	var b strings.Builder
+	b.WriteString("x,y\n")
+	for _, p := range positions {
+		fmt.Fprintf(&b, "%d,%d\n", p.X, p.Y)
+	}
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
+
+// pathExport is a GeoJSON-like LineString wrapping the exploration's path.
+type pathExport struct {
+	Type        string   `json:"type"`
+	Exploration string   `json:"exploration"`
+	Coordinates [][2]int `json:"coordinates"`
+}
+
+func writePathJSON(filename, name string, positions []Position) error {
+	coords := make([][2]int, len(positions))
+	for i, p := range positions {
+		coords[i] = [2]int{p.X, p.Y}
+	}
+
+	data, err := json.MarshalIndent(pathExport{
+		Type:        "LineString",
+		Exploration: name,
+		Coordinates: coords,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// writePathSVG renders the path as a standalone polyline overlay, scaled at
+// the same 20px cell size as the server's PNG render so the two can be
+// layered directly.
+func writePathSVG(filename string, grid MazeGridResponse, positions []Position) error {
+	const cellSize = 20
+	width := grid.Width * cellSize
+	height := grid.Height * cellSize
+
+	points := make([]string, len(positions))
+	for i, p := range positions {
+		x := p.X*cellSize + cellSize/2
+		y := p.Y*cellSize + cellSize/2
+		points[i] = fmt.Sprintf("%d,%d", x, y)
+	}
+
+	svg := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">
+<polyline points="%s" fill="none" stroke="#FF6D00" stroke-width="3" stroke-linecap="round" stroke-linejoin="round"/>
+</svg>
+`, width, height, strings.Join(points, " "))
+
+	return os.WriteFile(filename, []byte(svg), 0644)
+}
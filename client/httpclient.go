@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// mazeClient wraps *http.Client with a per-request timeout, exponential
+// backoff retry on 5xx/connection-refused, and a structured transaction log.
+type mazeClient struct {
+	http     *http.Client
+	timeout  time.Duration
+	retries  int
+	verbose  bool
+	log      io.Writer
+	logFile  *os.File
+	recorder *recorder
+}
+
+// newMazeClient builds a mazeClient. If logFilePath is empty, transaction
+// logging only goes to stderr, and only when verbose is set.
+func newMazeClient(timeout time.Duration, retries int, verbose bool, logFilePath string) (*mazeClient, error) {
+	c := &mazeClient{
+		http:    &http.Client{},
+		timeout: timeout,
+		retries: retries,
+		verbose: verbose,
+	}
+
+	if logFilePath != "" {
+		f, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %v", err)
+		}
+		c.logFile = f
+		c.log = f
+	} else if verbose {
+		c.log = os.Stderr
+	}
+
+	return c, nil
+}
+
+func (c *mazeClient) Close() {
+	if c.logFile != nil {
+		c.logFile.Close()
+	}
+}
+
+// request performs method/url with an optional JSON body, retrying on 5xx
+// responses or connection errors with exponential backoff, and returns the
+// fully-read response body so callers never have to manage a *http.Response
+// whose context outlives the retry loop. ctx is the caller's cancellation
+// scope (e.g. a REPL line's Ctrl-C); each attempt gets its own timeout
+// derived from it.
+func (c *mazeClient) request(ctx context.Context, method, url string, body []byte) (status int, respBody []byte, err error) {
+	if c.recorder != nil {
+		defer func() {
+			c.recorder.record(method, url, body, respBody, status)
+		}()
+	}
+
+	backoff := 200 * time.Millisecond
+
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, c.timeout)
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, reqErr := http.NewRequestWithContext(attemptCtx, method, url, reader)
+		if reqErr != nil {
+			cancel()
+			return 0, nil, reqErr
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, doErr := c.http.Do(req)
+		elapsed := time.Since(start)
+
+		if doErr != nil {
+			cancel()
+			c.logTransaction(method, url, 0, elapsed, 0)
+			err = doErr
+			if ctx.Err() != nil {
+				return 0, nil, ctx.Err()
+			}
+			if attempt < c.retries {
+				time.Sleep(backoff)
+				backoff *= 2
+				continue
+			}
+			return 0, nil, err
+		}
+
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+
+		c.logTransaction(method, url, resp.StatusCode, elapsed, len(data))
+
+		if readErr != nil {
+			return resp.StatusCode, nil, readErr
+		}
+
+		if resp.StatusCode >= 500 && attempt < c.retries {
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+
+		return resp.StatusCode, data, nil
+	}
+
+	return 0, nil, err
+}
+
+func (c *mazeClient) get(ctx context.Context, url string) (int, []byte, error) {
+	return c.request(ctx, "GET", url, nil)
+}
+
+func (c *mazeClient) post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	return c.request(ctx, "POST", url, body)
+}
+
+func (c *mazeClient) logTransaction(method, url string, status int, elapsed time.Duration, bytesRead int) {
+	if c.log == nil {
+		return
+	}
+	fmt.Fprintf(c.log, "%s %s -> %d (%s, %d bytes)\n", method, url, status, elapsed, bytesRead)
+}
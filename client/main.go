@@ -1,14 +1,14 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Direction struct {
@@ -27,14 +27,12 @@ type Position struct {
 }
 
 type MazeStatusResponse struct {
-	IsExplored           bool            `json:"is_explored"`
-	IsJunction           bool            `json:"is_junction"`
-	AvailableDirections  []Direction     `json:"available_directions"`
-	AvailableMoves       []AvailableMove `json:"available_moves"`
-	IsGoal               bool            `json:"is_goal"`
-	GoalReachedByAny     bool            `json:"goal_reached_by_any"`
-	ExplorationComplete  bool            `json:"exploration_complete"`
-	JunctionPositions    []Position      `json:"junction_positions"`
+	IsExplored          bool            `json:"is_explored"`
+	IsJunction          bool            `json:"is_junction"`
+	AvailableDirections []Direction     `json:"available_directions"`
+	AvailableMoves      []AvailableMove `json:"available_moves"`
+	IsGoal              bool            `json:"is_goal"`
+	GoalReachedByAny    bool            `json:"goal_reached_by_any"`
 }
 
 type MoveRequest struct {
@@ -74,6 +72,19 @@ type ExplorationTreeResponse struct {
 
 var ServerURL string
 
+// GameID is which server-side game this client targets. The server moved
+// every endpoint under /game/{id}/... for multi-game routing; this client
+// defaults to game #0 (the one main() on the server side always creates
+// first) and can be pointed elsewhere with --game.
+var GameID int
+
+// gameURL builds a full URL for path under this client's current game, e.g.
+// gameURL("move") -> "http://host:port/game/0/move". path may include its
+// own query string.
+func gameURL(path string) string {
+	return fmt.Sprintf("%s/game/%d/%s", ServerURL, GameID, path)
+}
+
 const ConfigFile = ".maze_server"
 
 func loadServerConfig() {
@@ -104,19 +115,48 @@ func handleSetCommand(host, port string) {
 	fmt.Println("💡 Configuration saved to .maze_server")
 }
 
+// client is the shared context-aware HTTP client used by every handler.
+// It's initialized in main() from the --timeout/--retries/--verbose/--log-file
+// flags so a REPL-level Ctrl-C or deadline can cancel in-flight calls cleanly.
+var client *mazeClient
+
 func main() {
+	timeout := flag.Duration("timeout", 5*time.Second, "Per-request HTTP timeout")
+	retries := flag.Int("retries", 3, "Retries on 5xx/connection-refused, with exponential backoff")
+	verbose := flag.Bool("verbose", false, "Log each HTTP transaction to stderr")
+	logFile := flag.String("log-file", "", "Log each HTTP transaction to this file instead of stderr")
+	game := flag.Int("game", 0, "Server-side game ID to target (/game/{id}/...)")
+	flag.Parse()
+	GameID = *game
+
+	var err error
+	client, err = newMazeClient(*timeout, *retries, *verbose, *logFile)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
 	// Load server configuration
 	loadServerConfig()
 
-	// Parse command line arguments
-	args := os.Args[1:] // Skip program name
+	// Parse remaining (non-flag) command line arguments
+	args := flag.Args()
+
+	ctx := context.Background()
 
 	if len(args) == 0 {
 		// No arguments: reset game
-		resetGame()
+		resetGame(ctx)
 		return
 	}
 
+	runCommand(ctx, args)
+}
+
+// runCommand dispatches a single top-level command. It's factored out of
+// main() so `record` can re-invoke it for the command it wraps.
+func runCommand(ctx context.Context, args []string) {
 	command := args[0]
 
 	switch command {
@@ -127,55 +167,76 @@ func main() {
 			return
 		}
 		handleSetCommand(args[1], args[2])
-		
+
 	case "start":
 		if len(args) != 4 {
 			fmt.Println("❌ Usage: maze_client start <exploration_name> <x> <y>")
 			fmt.Println("   Example: maze_client start root 1 1")
 			return
 		}
-		handleStartCommand(args[1], args[2], args[3])
-		
+		handleStartCommand(ctx, args[1], args[2], args[3])
+
 	case "status":
 		if len(args) != 2 {
 			fmt.Println("❌ Usage: maze_client status <exploration_name>")
 			fmt.Println("   Example: maze_client status root")
 			return
 		}
-		handleStatusCommand(args[1])
-		
+		handleStatusCommand(ctx, args[1])
+
 	case "move":
 		if len(args) != 4 {
 			fmt.Println("❌ Usage: maze_client move <exploration_name> <x> <y>")
 			fmt.Println("   Example: maze_client move root 2 1")
 			return
 		}
-		handleMoveCommand(args[1], args[2], args[3])
-		
+		handleMoveCommand(ctx, args[1], args[2], args[3])
+
 	case "render":
-		handleRenderCommand()
-		
+		handleRenderCommand(ctx, args[1:])
+
 	case "tree":
-		handleTreeCommand()
-		
+		handleTreeCommand(ctx)
+
+	case "solve":
+		handleSolveArgs(args[1:])
+
+	case "watch":
+		handleWatchArgs(args[1:])
+
+	case "record":
+		if len(args) < 3 {
+			fmt.Println("❌ Usage: maze_client record <file.jsonl> <command> [args...]")
+			return
+		}
+		handleRecordCommand(ctx, args[1], args[2:])
+
+	case "replay":
+		handleReplayArgs(args[1:])
+
+	case "export-path":
+		handleExportPathArgs(args[1:])
+
+	case "repl", "shell":
+		handleReplCommand()
+
 	default:
 		showUsage()
 	}
 }
 
-func resetGame() {
+func resetGame(ctx context.Context) {
 	fmt.Printf("🔄 Resetting game on %s...\n", ServerURL)
-	
-	resp, err := http.Post(fmt.Sprintf("%s/reset", ServerURL), "application/json", strings.NewReader("{}"))
+
+	status, _, err := client.post(ctx, gameURL("reset"), []byte("{}"))
 	if err != nil {
 		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		fmt.Println("💡 Make sure server is running and accessible")
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		fmt.Printf("❌ Server error: %s\n", resp.Status)
+	if status != 200 {
+		fmt.Printf("❌ Server error: status %d\n", status)
 		return
 	}
 
@@ -183,7 +244,7 @@ func resetGame() {
 	fmt.Println("💡 Start a new exploration with: maze_client start root 1 1")
 }
 
-func handleStartCommand(name, xStr, yStr string) {
+func handleStartCommand(ctx context.Context, name, xStr, yStr string) {
 	x, err1 := strconv.Atoi(xStr)
 	y, err2 := strconv.Atoi(yStr)
 
@@ -193,7 +254,7 @@ func handleStartCommand(name, xStr, yStr string) {
 	}
 
 	fmt.Printf("🚀 Starting exploration '%s' at (%d, %d)...\n", name, x, y)
-	
+
 	moveReq := MoveRequest{
 		ExplorationName: name,
 		NextPosition:    Position{x, y},
@@ -205,15 +266,14 @@ func handleStartCommand(name, xStr, yStr string) {
 		return
 	}
 
-	resp, err := http.Post(fmt.Sprintf("%s/move", ServerURL), "application/json", bytes.NewBuffer(jsonData))
+	_, respBody, err := client.post(ctx, gameURL("move"), jsonData)
 	if err != nil {
 		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
 
 	var moveResp MoveResponse
-	if err := json.NewDecoder(resp.Body).Decode(&moveResp); err != nil {
+	if err := json.Unmarshal(respBody, &moveResp); err != nil {
 		fmt.Printf("❌ Error parsing response: %v\n", err)
 		return
 	}
@@ -229,7 +289,7 @@ func handleStartCommand(name, xStr, yStr string) {
 func showUsage() {
 	fmt.Println("🎮 Maze Game Client")
 	fmt.Println("==================")
-	fmt.Printf("Current server: %s\n", ServerURL)
+	fmt.Printf("Current server: %s (game #%d, use --game to target another)\n", ServerURL, GameID)
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  maze_client                           - Reset game (clear all explorations)")
@@ -237,8 +297,18 @@ func showUsage() {
 	fmt.Println("  maze_client start <name> <x> <y>      - Start new exploration")
 	fmt.Println("  maze_client status <name>             - Check exploration status")
 	fmt.Println("  maze_client move <name> <x> <y>       - Move exploration")
-	fmt.Println("  maze_client render                    - Generate maze image")
+	fmt.Println("  maze_client render [--ascii]           - Generate maze image (or print it to the terminal)")
 	fmt.Println("  maze_client tree                      - Show exploration tree")
+	fmt.Println("  maze_client solve <name> <x> <y> [--strategy bfs|dfs|greedy] [--workers N] [--goal-x N --goal-y N]")
+	fmt.Println("                                         - Auto-solve by driving the server to completion")
+	fmt.Println("  maze_client watch [--interval N]      - Live ASCII view of the exploration tree, polling every N ms (default 500)")
+	fmt.Println("  maze_client record <file.jsonl> <command> [args...]")
+	fmt.Println("                                         - Run <command> (or repl) while appending every HTTP call to file.jsonl")
+	fmt.Println("  maze_client replay <file.jsonl> [--speed 1.0] [--against <server>]")
+	fmt.Println("                                         - Re-issue the move/reset calls recorded in file.jsonl")
+	fmt.Println("  maze_client export-path <name> --format {json,csv,svg}")
+	fmt.Println("                                         - Export an exploration's path in the given format")
+	fmt.Println("  maze_client repl                      - Interactive shell with history and tab completion")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  maze_client set 34.169.25.230 8079")
@@ -248,28 +318,27 @@ func showUsage() {
 	fmt.Println()
 }
 
-func handleStatusCommand(explorationName string) {
+func handleStatusCommand(ctx context.Context, explorationName string) {
 	fmt.Printf("🔍 Checking status of exploration '%s'...\n", explorationName)
-	
-	url := fmt.Sprintf("%s/exploration-status?name=%s", ServerURL, explorationName)
-	resp, err := http.Get(url)
+
+	url := gameURL(fmt.Sprintf("exploration-status?name=%s", explorationName))
+	statusCode, respBody, err := client.get(ctx, url)
 	if err != nil {
 		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 404 {
+	if statusCode == 404 {
 		fmt.Printf("❌ Exploration '%s' not found\n", explorationName)
 		fmt.Println("💡 Use: maze_client tree (to see all explorations)")
 		return
-	} else if resp.StatusCode != 200 {
-		fmt.Printf("❌ Server error: %s\n", resp.Status)
+	} else if statusCode != 200 {
+		fmt.Printf("❌ Server error: status %d\n", statusCode)
 		return
 	}
 
 	var status MazeStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+	if err := json.Unmarshal(respBody, &status); err != nil {
 		fmt.Printf("❌ Error parsing response: %v\n", err)
 		return
 	}
@@ -284,32 +353,24 @@ func displayMazeStatus(status MazeStatusResponse) {
 	fmt.Printf("  🛤️  Junction: %v\n", status.IsJunction)
 	fmt.Printf("  🎯 Goal: %v\n", status.IsGoal)
 	fmt.Printf("  🏆 Any reached goal: %v\n", status.GoalReachedByAny)
-	fmt.Printf("  ✅ Exploration complete: %v\n", status.ExplorationComplete)
-	
-	if status.ExplorationComplete {
-		if len(status.JunctionPositions) > 0 {
-			fmt.Printf("  🚀 Start new explorations at junction positions:\n")
-			for i, pos := range status.JunctionPositions {
-				fmt.Printf("    %d. maze_client start <new_exploration_name> %d %d\n", i+1, pos.X, pos.Y)
-			}
-		} else {
-			fmt.Printf("  💀 This exploration is complete (reached dead end/goal)\n")
-		}
+	if len(status.AvailableMoves) == 0 {
+		fmt.Printf("  💀 This exploration is complete (reached dead end/goal)\n")
+		return
+	}
+
+	fmt.Printf("  ➡️  Available moves (%d):\n", len(status.AvailableMoves))
+	for i, move := range status.AvailableMoves {
+		dirName := getDirectionName(move.Direction)
+		fmt.Printf("    %d. %s to (%d, %d)\n", i+1, dirName, move.TargetPosition.X, move.TargetPosition.Y)
+	}
+	if status.IsJunction {
+		fmt.Printf("  🚀 At a junction - branch with: maze_client move <new_exploration_name> <target_x> <target_y>\n")
 	} else {
-		if len(status.AvailableMoves) == 0 {
-			fmt.Printf("  ➡️  Available moves: None (blocked/wall)\n")
-		} else {
-			fmt.Printf("  ➡️  Available moves (%d):\n", len(status.AvailableMoves))
-			for i, move := range status.AvailableMoves {
-				dirName := getDirectionName(move.Direction)
-				fmt.Printf("    %d. %s to (%d, %d)\n", i+1, dirName, move.TargetPosition.X, move.TargetPosition.Y)
-			}
-			fmt.Printf("  💡 Use: maze_client move <exploration_name> <target_x> <target_y>\n")
-		}
+		fmt.Printf("  💡 Use: maze_client move <exploration_name> <target_x> <target_y>\n")
 	}
 }
 
-func handleMoveCommand(explorationName, xStr, yStr string) {
+func handleMoveCommand(ctx context.Context, explorationName, xStr, yStr string) {
 	x, err1 := strconv.Atoi(xStr)
 	y, err2 := strconv.Atoi(yStr)
 
@@ -319,7 +380,7 @@ func handleMoveCommand(explorationName, xStr, yStr string) {
 	}
 
 	fmt.Printf("🚀 Moving exploration '%s' to (%d, %d)...\n", explorationName, x, y)
-	
+
 	moveReq := MoveRequest{
 		ExplorationName: explorationName,
 		NextPosition:    Position{x, y},
@@ -331,15 +392,14 @@ func handleMoveCommand(explorationName, xStr, yStr string) {
 		return
 	}
 
-	resp, err := http.Post(fmt.Sprintf("%s/move", ServerURL), "application/json", bytes.NewBuffer(jsonData))
+	_, respBody, err := client.post(ctx, gameURL("move"), jsonData)
 	if err != nil {
 		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
 
 	var moveResp MoveResponse
-	if err := json.NewDecoder(resp.Body).Decode(&moveResp); err != nil {
+	if err := json.Unmarshal(respBody, &moveResp); err != nil {
 		fmt.Printf("❌ Error parsing response: %v\n", err)
 		return
 	}
@@ -353,21 +413,20 @@ func handleMoveCommand(explorationName, xStr, yStr string) {
 	}
 }
 
-func handleTreeCommand() {
-	resp, err := http.Get(fmt.Sprintf("%s/exploration-tree", ServerURL))
+func handleTreeCommand(ctx context.Context) {
+	statusCode, respBody, err := client.get(ctx, gameURL("exploration-tree"))
 	if err != nil {
 		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		fmt.Printf("❌ Server error: %s\n", resp.Status)
+	if statusCode != 200 {
+		fmt.Printf("❌ Server error: status %d\n", statusCode)
 		return
 	}
 
 	var tree ExplorationTreeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+	if err := json.Unmarshal(respBody, &tree); err != nil {
 		fmt.Printf("❌ Error parsing response: %v\n", err)
 		return
 	}
@@ -432,23 +491,22 @@ func getDirectionName(dir Direction) string {
 	}
 }
 
-func handleRenderCommand() {
-	resp, err := http.Post(fmt.Sprintf("%s/render", ServerURL), "application/json", strings.NewReader("{}"))
-	if err != nil {
-		fmt.Printf("❌ Error connecting to server: %v\n", err)
-		return
+func handleRenderCommand(ctx context.Context, args []string) {
+	for _, arg := range args {
+		if arg == "--ascii" {
+			renderAsciiOnce(ctx)
+			return
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		fmt.Printf("❌ Server error: %s\n", resp.Status)
+	statusCode, pngContent, err := client.post(ctx, gameURL("render"), []byte("{}"))
+	if err != nil {
+		fmt.Printf("❌ Error connecting to server: %v\n", err)
 		return
 	}
 
-	// Read PNG content from response
-	pngContent, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Printf("❌ Error reading response: %v\n", err)
+	if statusCode != 200 {
+		fmt.Printf("❌ Server error: status %d\n", statusCode)
 		return
 	}
 
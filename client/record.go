@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordEntry is one JSON line of a trace file, capturing everything needed
+// to either inspect or replay a past server interaction.
+type recordEntry struct {
+	Timestamp    string          `json:"timestamp"`
+	Command      string          `json:"command"`
+	RequestBody  json.RawMessage `json:"request_body"`
+	ResponseBody json.RawMessage `json:"response_body"`
+	StatusCode   int             `json:"status_code"`
+}
+
+// recorder appends one recordEntry per HTTP transaction to a trace file. It's
+// attached to the shared mazeClient so every handler's calls are captured
+// transparently, including concurrent callers from `solve`.
+type recorder struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newRecorder(path string) (*recorder, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %v", err)
+	}
+	return &recorder{file: f}, nil
+}
+
+func (r *recorder) record(method, url string, reqBody, respBody []byte, status int) {
+	entry := recordEntry{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		Command:      fmt.Sprintf("%s %s", method, url),
+		RequestBody:  rawOrNull(reqBody),
+		ResponseBody: rawOrNull(respBody),
+		StatusCode:   status,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.file.Write(data)
+}
+
+func (r *recorder) Close() {
+	r.file.Close()
+}
+
+func rawOrNull(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(b)
+}
+
+// handleRecordCommand attaches a recorder to the shared client, then runs
+// innerArgs as an ordinary command (including "repl"), so every HTTP call it
+// makes is appended to file as it happens.
+func handleRecordCommand(ctx context.Context, file string, innerArgs []string) {
+	rec, err := newRecorder(file)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	defer rec.Close()
+
+	client.recorder = rec
+	fmt.Printf("🔴 Recording to %s...\n", file)
+	runCommand(ctx, innerArgs)
+}
+
+// handleReplayArgs parses `replay <file.jsonl> [--speed 1.0] [--against <server>]`
+// and dispatches to handleReplayCommand.
+func handleReplayArgs(args []string) {
+	if len(args) < 1 {
+		fmt.Println("❌ Usage: maze_client replay <file.jsonl> [--speed 1.0] [--against <server>]")
+		return
+	}
+
+	file := args[0]
+	speed := 1.0
+
+	rest := args[1:]
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "--speed":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --speed requires a value")
+				return
+			}
+			i++
+			s, err := strconv.ParseFloat(rest[i], 64)
+			if err != nil || s <= 0 {
+				fmt.Println("❌ --speed must be a positive number")
+				return
+			}
+			speed = s
+		case "--against":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --against requires a server URL")
+				return
+			}
+			i++
+			ServerURL = rest[i]
+		default:
+			fmt.Printf("❌ Unknown flag '%s'\n", rest[i])
+			return
+		}
+	}
+
+	handleReplayCommand(context.Background(), file, speed)
+}
+
+// handleReplayCommand re-issues the move/reset calls recorded in file, in
+// order, against ServerURL. Gaps between recorded timestamps are preserved
+// (scaled by speed) so a replay looks like the original session.
+func handleReplayCommand(ctx context.Context, file string, speed float64) {
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Printf("❌ Error opening trace file: %v\n", err)
+		return
+	}
+	defer f.Close()
+
+	fmt.Printf("▶️  Replaying %s against %s at %.1fx speed...\n", file, ServerURL, speed)
+
+	scanner := bufio.NewScanner(f)
+	var prevTimestamp time.Time
+	haveTimestamp := false
+	count := 0
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry recordEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+
+		path, ok := replayablePath(entry.Command)
+		if !ok {
+			continue
+		}
+
+		if ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+			if haveTimestamp {
+				gap := ts.Sub(prevTimestamp)
+				if speed > 0 {
+					gap = time.Duration(float64(gap) / speed)
+				}
+				if gap > 0 {
+					time.Sleep(gap)
+				}
+			}
+			prevTimestamp = ts
+			haveTimestamp = true
+		}
+
+		body := []byte(entry.RequestBody)
+		if len(body) == 0 || string(body) == "null" {
+			body = []byte("{}")
+		}
+
+		statusCode, _, err := client.post(ctx, fmt.Sprintf("%s%s", ServerURL, path), body)
+		count++
+		if err != nil {
+			fmt.Printf("❌ [%d] POST %s failed: %v\n", count, path, err)
+			continue
+		}
+		fmt.Printf("✅ [%d] POST %s -> %d\n", count, path, statusCode)
+	}
+
+	fmt.Printf("🏁 Replay finished: %d call(s) issued\n", count)
+}
+
+// replayablePath extracts the URL path from a recorded "METHOD url" command
+// string, if it's one of the calls replay re-issues (move and reset; start
+// is itself a /move call). Recorded paths are /game/{id}/move or
+// /game/{id}/reset, so it matches on the action suffix rather than the
+// full path.
+func replayablePath(command string) (string, bool) {
+	parts := strings.SplitN(command, " ", 2)
+	if len(parts) != 2 || parts[0] != "POST" {
+		return "", false
+	}
+
+	u, err := url.Parse(parts[1])
+	if err != nil {
+		return "", false
+	}
+
+	switch {
+	case strings.HasSuffix(u.Path, "/move"), strings.HasSuffix(u.Path, "/reset"):
+		return u.Path, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/chzyer/readline"
+)
+
+// handleReplCommand drops the user into an interactive prompt that keeps an
+// implicit "current exploration" so verbs don't need to repeat its name, and
+// avoids paying process-startup + config-reload cost on every command.
+func handleReplCommand() {
+	historyFile := replHistoryPath()
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "maze> ",
+		HistoryFile:     historyFile,
+		AutoComplete:    replCompleter(),
+		InterruptPrompt: "^C",
+		EOFPrompt:       "quit",
+	})
+	if err != nil {
+		fmt.Printf("❌ Error starting REPL: %v\n", err)
+		return
+	}
+	defer rl.Close()
+
+	fmt.Printf("🎮 Maze Game REPL (server: %s)\n", ServerURL)
+	fmt.Println("💡 Type 'quit' or Ctrl-D to exit. Ctrl-C cancels the in-flight request and returns to the prompt.")
+
+	current := "root"
+
+	for {
+		line, err := rl.Readline()
+		if err == readline.ErrInterrupt {
+			// Ctrl-C at an empty prompt: nothing in flight, just redraw.
+			continue
+		}
+		if err != nil { // io.EOF (Ctrl-D)
+			break
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "quit" || line == "exit" {
+			break
+		}
+
+		runReplLine(line, &current)
+	}
+
+	fmt.Println("👋 Bye")
+}
+
+// runReplLine executes one REPL command under a cancelable context so a
+// SIGINT delivered while the request is in flight aborts the HTTP call
+// without killing the REPL itself.
+func runReplLine(line string, current *string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT)
+	defer signal.Stop(sigCh)
+
+	requestDone := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+			fmt.Println("\n⏹️  Cancelling request...")
+			cancel()
+		case <-requestDone:
+		}
+	}()
+
+	dispatchReplCommand(ctx, fields, current)
+	close(requestDone)
+}
+
+func dispatchReplCommand(ctx context.Context, fields []string, current *string) {
+	switch fields[0] {
+	case "start":
+		if len(fields) != 4 {
+			fmt.Println("❌ Usage: start <name> <x> <y>")
+			return
+		}
+		if replMove(ctx, fields[1], fields[2], fields[3]) {
+			*current = fields[1]
+		}
+
+	case "status":
+		name := *current
+		if len(fields) == 2 {
+			name = fields[1]
+		}
+		replStatus(ctx, name)
+
+	case "move":
+		if len(fields) != 3 {
+			fmt.Println("❌ Usage: move <x> <y> (moves the current exploration)")
+			return
+		}
+		replMove(ctx, *current, fields[1], fields[2])
+
+	case "tree":
+		replTree(ctx)
+
+	case "render":
+		replRender(ctx)
+
+	case "set":
+		if len(fields) != 3 {
+			fmt.Println("❌ Usage: set <host> <port>")
+			return
+		}
+		handleSetCommand(fields[1], fields[2])
+
+	case "use":
+		if len(fields) != 2 {
+			fmt.Println("❌ Usage: use <name> (sets the current exploration)")
+			return
+		}
+		*current = fields[1]
+		fmt.Printf("📌 Current exploration: %s\n", *current)
+
+	default:
+		fmt.Printf("❓ Unknown command '%s'. Known: start, status, move, tree, render, set, use, quit\n", fields[0])
+	}
+}
+
+func replMove(ctx context.Context, name, xStr, yStr string) bool {
+	x, err1 := strconv.Atoi(xStr)
+	y, err2 := strconv.Atoi(yStr)
+	if err1 != nil || err2 != nil {
+		fmt.Println("❌ Invalid coordinates. Use integers.")
+		return false
+	}
+
+	body, _ := json.Marshal(MoveRequest{ExplorationName: name, NextPosition: Position{x, y}})
+	_, respBody, err := client.post(ctx, gameURL("move"), body)
+	if err != nil {
+		fmt.Printf("❌ Error connecting to server: %v\n", err)
+		return false
+	}
+
+	var moveResp MoveResponse
+	if err := json.Unmarshal(respBody, &moveResp); err != nil {
+		fmt.Printf("❌ Error parsing response: %v\n", err)
+		return false
+	}
+
+	if moveResp.Success {
+		fmt.Printf("✅ %s (status: %s)\n", moveResp.Message, moveResp.NewStatus)
+	} else {
+		fmt.Printf("❌ %s (status: %s)\n", moveResp.Message, moveResp.NewStatus)
+	}
+	return moveResp.Success
+}
+
+func replStatus(ctx context.Context, name string) {
+	statusCode, respBody, err := client.get(ctx, gameURL(fmt.Sprintf("exploration-status?name=%s", name)))
+	if err != nil {
+		fmt.Printf("❌ Error connecting to server: %v\n", err)
+		return
+	}
+
+	if statusCode == 404 {
+		fmt.Printf("❌ Exploration '%s' not found\n", name)
+		return
+	}
+
+	var status MazeStatusResponse
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		fmt.Printf("❌ Error parsing response: %v\n", err)
+		return
+	}
+
+	fmt.Printf("📍 Exploration '%s' status:\n", name)
+	displayMazeStatus(status)
+}
+
+func replTree(ctx context.Context) {
+	_, respBody, err := client.get(ctx, gameURL("exploration-tree"))
+	if err != nil {
+		fmt.Printf("❌ Error connecting to server: %v\n", err)
+		return
+	}
+
+	var tree ExplorationTreeResponse
+	if err := json.Unmarshal(respBody, &tree); err != nil {
+		fmt.Printf("❌ Error parsing response: %v\n", err)
+		return
+	}
+
+	fmt.Printf("🌳 Total: %d | Active: %d | Goal: %v | Visited: %d\n",
+		tree.GlobalStats.TotalExplorations, tree.GlobalStats.ActiveExplorations,
+		tree.GlobalStats.GoalFound, tree.GlobalStats.VisitedPositions)
+}
+
+func replRender(ctx context.Context) {
+	statusCode, pngContent, err := client.post(ctx, gameURL("render"), []byte("{}"))
+	if err != nil {
+		fmt.Printf("❌ Error connecting to server: %v\n", err)
+		return
+	}
+	if statusCode != 200 {
+		fmt.Printf("❌ Server error: status %d\n", statusCode)
+		return
+	}
+
+	if err := os.WriteFile("maze.png", pngContent, 0644); err != nil {
+		fmt.Printf("❌ Error writing file: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Maze rendered to maze.png (%d bytes)\n", len(pngContent))
+}
+
+// replExplorationNames fetches known exploration names for tab completion.
+func replExplorationNames(string) []string {
+	_, respBody, err := client.get(context.Background(), gameURL("exploration-tree"))
+	if err != nil {
+		return nil
+	}
+
+	var tree ExplorationTreeResponse
+	if err := json.Unmarshal(respBody, &tree); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(tree.Explorations))
+	for name := range tree.Explorations {
+		names = append(names, name)
+	}
+	return names
+}
+
+func replCompleter() readline.AutoCompleter {
+	return readline.NewPrefixCompleter(
+		readline.PcItem("start"),
+		readline.PcItem("status", readline.PcItemDynamic(replExplorationNames)),
+		readline.PcItem("move"),
+		readline.PcItem("tree"),
+		readline.PcItem("render"),
+		readline.PcItem("set"),
+		readline.PcItem("use", readline.PcItemDynamic(replExplorationNames)),
+		readline.PcItem("quit"),
+	)
+}
+
+func replHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".maze_history"
+	}
+	return filepath.Join(home, ".maze_history")
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// solveStrategy selects how the frontier of pending exploration starts is ordered.
+type solveStrategy string
+
+const (
+	strategyBFS    solveStrategy = "bfs"
+	strategyDFS    solveStrategy = "dfs"
+	strategyGreedy solveStrategy = "greedy"
+)
+
+// frontierEntry is a pending (re)start of an exploration at a given position.
+type frontierEntry struct {
+	explorationName string
+	position        Position
+}
+
+// priorityEntry wraps a frontierEntry with its Manhattan distance to the goal
+// hint, used by container/heap for the greedy strategy.
+type priorityEntry struct {
+	entry    frontierEntry
+	priority int
+}
+
+type priorityQueue []*priorityEntry
+
+func (pq priorityQueue) Len() int            { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool  { return pq[i].priority < pq[j].priority }
+func (pq priorityQueue) Swap(i, j int)       { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *priorityQueue) Push(x interface{}) { *pq = append(*pq, x.(*priorityEntry)) }
+func (pq *priorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
+
+// frontier is a queue/stack/priority-queue of pending exploration starts,
+// selected by strategy: FIFO for bfs, LIFO for dfs, min-heap by Manhattan
+// distance to goalHint for greedy. Safe for concurrent use by a worker pool.
+type frontier struct {
+	mu       sync.Mutex
+	strategy solveStrategy
+	goalHint Position
+	fifo     []frontierEntry
+	pq       priorityQueue
+}
+
+func newFrontier(strategy solveStrategy, goalHint Position) *frontier {
+	return &frontier{strategy: strategy, goalHint: goalHint}
+}
+
+func manhattan(a, b Position) int {
+	return absInt(a.X-b.X) + absInt(a.Y-b.Y)
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (f *frontier) push(e frontierEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.strategy == strategyGreedy {
+		heap.Push(&f.pq, &priorityEntry{entry: e, priority: manhattan(e.position, f.goalHint)})
+		return
+	}
+	f.fifo = append(f.fifo, e)
+}
+
+// pop returns the next entry, or ok=false if the frontier is currently empty.
+func (f *frontier) pop() (frontierEntry, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch f.strategy {
+	case strategyGreedy:
+		if f.pq.Len() == 0 {
+			return frontierEntry{}, false
+		}
+		return heap.Pop(&f.pq).(*priorityEntry).entry, true
+	case strategyDFS:
+		if len(f.fifo) == 0 {
+			return frontierEntry{}, false
+		}
+		last := f.fifo[len(f.fifo)-1]
+		f.fifo = f.fifo[:len(f.fifo)-1]
+		return last, true
+	default: // bfs
+		if len(f.fifo) == 0 {
+			return frontierEntry{}, false
+		}
+		first := f.fifo[0]
+		f.fifo = f.fifo[1:]
+		return first, true
+	}
+}
+
+// solveStats accumulates a summary printed once the search finishes.
+type solveStats struct {
+	nodesExpanded int64
+	started       time.Time
+	winningName   atomic.Value // string
+}
+
+// handleSolveArgs parses `solve <root_name> <x> <y> [--strategy bfs|dfs|greedy]
+// [--workers N] [--goal-x N --goal-y N]` and dispatches to handleSolveCommand.
+func handleSolveArgs(args []string) {
+	if len(args) < 3 {
+		fmt.Println("❌ Usage: maze_client solve <root_name> <start_x> <start_y> [--strategy bfs|dfs|greedy] [--workers N] [--goal-x N --goal-y N]")
+		return
+	}
+
+	rootName, xStr, yStr := args[0], args[1], args[2]
+	strategy := strategyBFS
+	workers := 1
+	goalHint := Position{}
+
+	rest := args[3:]
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "--strategy":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --strategy requires a value")
+				return
+			}
+			i++
+			switch rest[i] {
+			case "bfs", "dfs", "greedy":
+				strategy = solveStrategy(rest[i])
+			default:
+				fmt.Printf("❌ Unknown strategy '%s' (expected bfs, dfs, or greedy)\n", rest[i])
+				return
+			}
+		case "--workers":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --workers requires a value")
+				return
+			}
+			i++
+			n, err := strconv.Atoi(rest[i])
+			if err != nil || n < 1 {
+				fmt.Println("❌ --workers must be a positive integer")
+				return
+			}
+			workers = n
+		case "--goal-x":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --goal-x requires a value")
+				return
+			}
+			i++
+			n, err := strconv.Atoi(rest[i])
+			if err != nil {
+				fmt.Println("❌ --goal-x must be an integer")
+				return
+			}
+			goalHint.X = n
+		case "--goal-y":
+			if i+1 >= len(rest) {
+				fmt.Println("❌ --goal-y requires a value")
+				return
+			}
+			i++
+			n, err := strconv.Atoi(rest[i])
+			if err != nil {
+				fmt.Println("❌ --goal-y must be an integer")
+				return
+			}
+			goalHint.Y = n
+		default:
+			fmt.Printf("❌ Unknown flag '%s'\n", rest[i])
+			return
+		}
+	}
+
+	if strategy == strategyGreedy && goalHint == (Position{}) {
+		fmt.Println("⚠️  No --goal-x/--goal-y hint given for greedy strategy; defaulting to (0, 0)")
+	}
+
+	handleSolveCommand(rootName, xStr, yStr, strategy, workers, goalHint)
+}
+
+// handleSolveCommand drives the server to completion autonomously using a
+// worker pool over the frontier of pending exploration starts. Workers stop
+// as soon as the goal is reached by any exploration or the frontier is fully
+// drained with no worker still expanding a node.
+func handleSolveCommand(rootName, xStr, yStr string, strategy solveStrategy, workers int, goalHint Position) {
+	x, err1 := strconv.Atoi(xStr)
+	y, err2 := strconv.Atoi(yStr)
+	if err1 != nil || err2 != nil {
+		fmt.Println("❌ Invalid coordinates. Use integers.")
+		return
+	}
+
+	fmt.Printf("🧠 Solving from '%s' at (%d, %d) using %s with %d worker(s)...\n", rootName, x, y, strategy, workers)
+
+	ctx := context.Background()
+	f := newFrontier(strategy, goalHint)
+	f.push(frontierEntry{explorationName: rootName, position: Position{x, y}})
+
+	var visited sync.Map // Position -> bool, local dedup so workers don't re-expand the same cell
+	// Every frontier entry is claimed in visited before it's pushed, so
+	// workers never re-check it on pop.
+	visited.Store(Position{x, y}, true)
+	var genCounter int64
+	var inFlight int64
+	stats := &solveStats{started: time.Now()}
+	done := make(chan struct{})
+	var closeOnce sync.Once
+	stop := func() { closeOnce.Do(func() { close(done) }) }
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				entry, ok := f.pop()
+				if !ok {
+					if atomic.LoadInt64(&inFlight) == 0 {
+						stop()
+						return
+					}
+					time.Sleep(20 * time.Millisecond)
+					continue
+				}
+
+				atomic.AddInt64(&inFlight, 1)
+				foundGoal := runExploration(ctx, entry, &visited, stats, f, rootName, &genCounter)
+				atomic.AddInt64(&inFlight, -1)
+
+				if foundGoal {
+					stats.winningName.Store(entry.explorationName)
+					stop()
+					return
+				}
+
+				if goalReachedByAny(ctx) {
+					stop()
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	elapsed := time.Since(stats.started)
+	fmt.Println("🏁 Solve finished")
+	fmt.Printf("   🔎 Nodes expanded: %d\n", atomic.LoadInt64(&stats.nodesExpanded))
+	fmt.Printf("   ⏱️  Wall time: %s\n", elapsed)
+	if winner, ok := stats.winningName.Load().(string); ok && winner != "" {
+		fmt.Printf("   🏆 Goal reached by: %s\n", winner)
+		if length, ok := queryExplorationPathLength(ctx, winner); ok {
+			fmt.Printf("   🛤️  Path length: %d\n", length)
+		}
+	} else {
+		fmt.Println("   💀 Goal not reached (frontier exhausted)")
+	}
+}
+
+// runExploration drives a single exploration (starting at entry.position,
+// then following its first available move until a dead end or the goal),
+// reporting whether the goal was found. The server's MazeStatusResponse has
+// no notion of "exploration complete" or pre-computed junction positions -
+// only is_junction and available_moves for the current cell - so every time
+// this exploration sits at a junction, it forks a new frontier entry for
+// each extra branch (available_moves[1:]) right there and keeps following
+// available_moves[0] itself, instead of waiting for a status field that
+// never arrives.
+func runExploration(ctx context.Context, entry frontierEntry, visited *sync.Map, stats *solveStats, f *frontier, rootName string, genCounter *int64) (foundGoal bool) {
+	if !solveMove(ctx, entry.explorationName, entry.position) {
+		return false
+	}
+
+	for {
+		status, ok := solveStatus(ctx, entry.explorationName)
+		if !ok {
+			return false
+		}
+		atomic.AddInt64(&stats.nodesExpanded, 1)
+
+		if status.IsGoal {
+			return true
+		}
+
+		if len(status.AvailableMoves) == 0 {
+			return false
+		}
+
+		if status.IsJunction {
+			for _, branch := range status.AvailableMoves[1:] {
+				if _, alreadyQueued := visited.LoadOrStore(branch.TargetPosition, true); alreadyQueued {
+					continue
+				}
+				n := atomic.AddInt64(genCounter, 1)
+				childName := fmt.Sprintf("%s.%d", rootName, n)
+				f.push(frontierEntry{explorationName: childName, position: branch.TargetPosition})
+			}
+		}
+
+		next := status.AvailableMoves[0].TargetPosition
+		if _, already := visited.LoadOrStore(next, true); already {
+			return false
+		}
+		if !solveMove(ctx, entry.explorationName, next) {
+			return false
+		}
+	}
+}
+
+// solveMove issues a /move call and reports whether the server accepted it.
+func solveMove(ctx context.Context, explorationName string, pos Position) bool {
+	req := MoveRequest{ExplorationName: explorationName, NextPosition: pos}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false
+	}
+	statusCode, respBody, err := client.post(ctx, gameURL("move"), body)
+	if err != nil || statusCode != 200 {
+		return false
+	}
+
+	var moveResp MoveResponse
+	if err := json.Unmarshal(respBody, &moveResp); err != nil {
+		return false
+	}
+	return moveResp.Success
+}
+
+// solveStatus fetches the current MazeStatusResponse for an exploration.
+func solveStatus(ctx context.Context, explorationName string) (MazeStatusResponse, bool) {
+	statusCode, respBody, err := client.get(ctx, gameURL(fmt.Sprintf("exploration-status?name=%s", explorationName)))
+	if err != nil || statusCode != 200 {
+		return MazeStatusResponse{}, false
+	}
+
+	var status MazeStatusResponse
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return MazeStatusResponse{}, false
+	}
+	return status, true
+}
+
+// goalReachedByAny polls the exploration tree's global stats to check
+// whether any exploration (not just this worker's) has already won.
+func goalReachedByAny(ctx context.Context) bool {
+	statusCode, respBody, err := client.get(ctx, gameURL("exploration-tree"))
+	if err != nil || statusCode != 200 {
+		return false
+	}
+
+	var tree ExplorationTreeResponse
+	if err := json.Unmarshal(respBody, &tree); err != nil {
+		return false
+	}
+	return tree.GlobalStats.GoalFound
+}
+
+// queryExplorationPathLength looks up the winning exploration's path length
+// from the tree endpoint for the final summary.
+func queryExplorationPathLength(ctx context.Context, name string) (int, bool) {
+	statusCode, respBody, err := client.get(ctx, gameURL("exploration-tree"))
+	if err != nil || statusCode != 200 {
+		return 0, false
+	}
+
+	var tree ExplorationTreeResponse
+	if err := json.Unmarshal(respBody, &tree); err != nil {
+		return 0, false
+	}
+	exp, ok := tree.Explorations[name]
+	if !ok {
+		return 0, false
+	}
+	return len(exp.PathPositions), true
+}
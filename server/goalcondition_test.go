@@ -0,0 +1,104 @@
+package main
+
+import "testing"
+
+func TestPathVisitsAnyAndAll(t *testing.T) {
+	path := []Position{{0, 0}, {1, 0}, {2, 0}}
+	targets := []Position{{1, 0}, {5, 5}}
+
+	if !pathVisitsAny(path, targets) {
+		t.Errorf("pathVisitsAny() = false, want true (path contains one target)")
+	}
+	if pathVisitsAll(path, targets) {
+		t.Errorf("pathVisitsAll() = true, want false (path is missing {5,5})")
+	}
+
+	allPresent := []Position{{1, 0}, {2, 0}}
+	if !pathVisitsAll(path, allPresent) {
+		t.Errorf("pathVisitsAll() = false, want true (path contains every target)")
+	}
+	if pathVisitsAny(path, nil) {
+		t.Errorf("pathVisitsAny(nil targets) = true, want false")
+	}
+}
+
+func TestCheckWinConditionGoalSingle(t *testing.T) {
+	g := &Game{GoalMode: GoalSingle, Goals: []Position{{3, 3}}}
+
+	atGoal := &Exploration{CurrentPosition: Position{3, 3}}
+	if !g.checkWinCondition(atGoal) {
+		t.Errorf("checkWinCondition() = false at the goal cell, want true")
+	}
+
+	notAtGoal := &Exploration{CurrentPosition: Position{0, 0}}
+	if g.checkWinCondition(notAtGoal) {
+		t.Errorf("checkWinCondition() = true away from the goal cell, want false")
+	}
+}
+
+func TestCheckWinConditionCollectReturn(t *testing.T) {
+	g := &Game{
+		GoalMode: GoalCollectReturn,
+		Start:    Position{0, 0},
+		Goals:    []Position{{0, 0}},
+		Items:    []Position{{2, 2}},
+	}
+
+	cases := []struct {
+		name string
+		exp  *Exploration
+		want bool
+	}{
+		{
+			name: "back at start having visited the item",
+			exp:  &Exploration{CurrentPosition: Position{0, 0}, PathPositions: []Position{{0, 0}, {2, 2}, {0, 0}}},
+			want: true,
+		},
+		{
+			name: "at start but never visited the item",
+			exp:  &Exploration{CurrentPosition: Position{0, 0}, PathPositions: []Position{{0, 0}, {1, 1}, {0, 0}}},
+			want: false,
+		},
+		{
+			name: "visited the item but hasn't returned to start",
+			exp:  &Exploration{CurrentPosition: Position{2, 2}, PathPositions: []Position{{0, 0}, {2, 2}}},
+			want: false,
+		},
+	}
+	for _, tc := range cases {
+		if got := g.checkWinCondition(tc.exp); got != tc.want {
+			t.Errorf("%s: checkWinCondition() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCheckWinConditionCollectAll(t *testing.T) {
+	g := &Game{
+		GoalMode: GoalCollectAll,
+		Goals:    []Position{{4, 4}},
+		Items:    []Position{{1, 1}, {2, 2}},
+	}
+
+	allVisited := &Exploration{CurrentPosition: Position{4, 4}, PathPositions: []Position{{1, 1}, {2, 2}, {4, 4}}}
+	if !g.checkWinCondition(allVisited) {
+		t.Errorf("checkWinCondition() = false with every item visited and at the goal, want true")
+	}
+
+	oneMissing := &Exploration{CurrentPosition: Position{4, 4}, PathPositions: []Position{{1, 1}, {4, 4}}}
+	if g.checkWinCondition(oneMissing) {
+		t.Errorf("checkWinCondition() = true with an item unvisited, want false")
+	}
+}
+
+func TestItemsRemaining(t *testing.T) {
+	g := &Game{Items: []Position{{1, 1}, {2, 2}, {3, 3}}}
+
+	if got, want := g.itemsRemaining(nil), 3; got != want {
+		t.Errorf("itemsRemaining(nil) = %d, want %d", got, want)
+	}
+
+	visited := []Position{{2, 2}}
+	if got, want := g.itemsRemaining(visited), 2; got != want {
+		t.Errorf("itemsRemaining(%v) = %d, want %d", visited, got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import "testing"
+
+func TestKDTreeInsertContainsLen(t *testing.T) {
+	tree := newKDTree()
+	if tree.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 on empty tree", tree.Len())
+	}
+
+	positions := []Position{{0, 0}, {3, 1}, {1, 4}, {3, 1}}
+	for _, p := range positions {
+		tree.Insert(p)
+	}
+
+	if got, want := tree.Len(), 3; got != want {
+		t.Fatalf("Len() = %d, want %d (duplicate insert must be a no-op)", got, want)
+	}
+	for _, p := range []Position{{0, 0}, {3, 1}, {1, 4}} {
+		if !tree.Contains(p) {
+			t.Errorf("Contains(%v) = false, want true", p)
+		}
+	}
+	if tree.Contains(Position{9, 9}) {
+		t.Errorf("Contains({9,9}) = true, want false")
+	}
+}
+
+func TestKDTreeAll(t *testing.T) {
+	tree := newKDTree()
+	want := map[Position]bool{{0, 0}: true, {5, 2}: true, {-1, 3}: true}
+	for p := range want {
+		tree.Insert(p)
+	}
+
+	got := tree.All()
+	if len(got) != len(want) {
+		t.Fatalf("All() returned %d positions, want %d", len(got), len(want))
+	}
+	for _, p := range got {
+		if !want[p] {
+			t.Errorf("All() returned unexpected position %v", p)
+		}
+	}
+}
+
+func TestKDTreeVisitedInRadius(t *testing.T) {
+	tree := newKDTree()
+	for _, p := range []Position{{0, 0}, {1, 0}, {0, 5}, {3, 4}} {
+		tree.Insert(p)
+	}
+
+	got := tree.VisitedInRadius(Position{0, 0}, 1)
+	want := map[Position]bool{{0, 0}: true, {1, 0}: true}
+	if len(got) != len(want) {
+		t.Fatalf("VisitedInRadius(r=1) = %v, want exactly %v", got, want)
+	}
+	for _, p := range got {
+		if !want[p] {
+			t.Errorf("VisitedInRadius(r=1) included unexpected %v", p)
+		}
+	}
+}
+
+func TestKDTreeRemove(t *testing.T) {
+	tree := newKDTree()
+	for _, p := range []Position{{0, 0}, {1, 1}, {2, 2}} {
+		tree.Insert(p)
+	}
+
+	tree.Remove(Position{1, 1})
+	if tree.Contains(Position{1, 1}) {
+		t.Fatalf("Contains({1,1}) = true after Remove")
+	}
+	if got, want := tree.Len(), 2; got != want {
+		t.Fatalf("Len() = %d after Remove, want %d", got, want)
+	}
+	for _, p := range []Position{{0, 0}, {2, 2}} {
+		if !tree.Contains(p) {
+			t.Errorf("Remove of an unrelated position also dropped %v", p)
+		}
+	}
+
+	// Removing something never inserted is a no-op, not an error.
+	tree.Remove(Position{9, 9})
+	if tree.Len() != 2 {
+		t.Fatalf("Len() = %d after removing a missing position, want 2", tree.Len())
+	}
+}
+
+// newTestGame builds a minimal all-PATH maze of the given size, big enough
+// for nearestUnvisited to search without tripping isWalkable's bounds check.
+func newTestGame(width, height int) *Game {
+	maze := make([][]CellType, height)
+	for y := range maze {
+		maze[y] = make([]CellType, width)
+		for x := range maze[y] {
+			maze[y][x] = PATH
+		}
+	}
+	return &Game{
+		Maze:                   maze,
+		Width:                  width,
+		Height:                 height,
+		GlobalVisitedPositions: newKDTree(),
+	}
+}
+
+func TestNearestUnvisitedPrefersCloserRing(t *testing.T) {
+	g := newTestGame(11, 11)
+	from := Position{5, 5}
+
+	// Mark from itself and one neighbor as visited. Ring 1 then still holds
+	// three unvisited neighbors at sqDist 1, but its first cells in ring
+	// order are diagonals at sqDist 2 - nearestUnvisited must keep the
+	// closest candidate of the ring, not the first one it meets.
+	g.GlobalVisitedPositions.Insert(from)
+	g.GlobalVisitedPositions.Insert(Position{6, 5})
+
+	got, found := g.nearestUnvisited(from)
+	if !found {
+		t.Fatalf("nearestUnvisited() found = false, want true")
+	}
+	if got == (Position{6, 5}) {
+		t.Fatalf("nearestUnvisited() = %v, which is already visited", got)
+	}
+	if sqDist(from, got) != 1 {
+		t.Fatalf("nearestUnvisited() = %v (sqDist %d from %v), want an adjacent unvisited cell", got, sqDist(from, got), from)
+	}
+}
+
+func TestNearestUnvisitedNoneLeft(t *testing.T) {
+	g := newTestGame(3, 3)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			g.GlobalVisitedPositions.Insert(Position{x, y})
+		}
+	}
+
+	if _, found := g.nearestUnvisited(Position{1, 1}); found {
+		t.Fatalf("nearestUnvisited() found = true with every cell visited, want false")
+	}
+}
@@ -2,23 +2,38 @@ package main
 
 import (
 	"bytes"
+	"container/heap"
+	"encoding/binary"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"image"
 	"image/color"
+	"image/color/palette"
 	"image/draw"
+	"image/gif"
 	"image/png"
 	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
-	
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/llgcode/draw2d/draw2dkit"
 	"golang.org/x/image/font"
-	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/math/fixed"
 )
 
@@ -45,8 +60,45 @@ const (
 	PATH
 	START
 	GOAL
+	// ITEM marks a cell an exploration must visit to satisfy GoalCollectReturn
+	// or GoalCollectAll - see checkWinCondition.
+	ITEM
+)
+
+// GoalMode selects which win condition checkWinCondition enforces.
+type GoalMode string
+
+const (
+	// GoalSingle wins the moment an exploration reaches Goal. The default.
+	GoalSingle GoalMode = "single"
+	// GoalMulti wins at any cell in Goals, not just the first one picked.
+	GoalMulti GoalMode = "multi-goal"
+	// GoalCollectReturn wins by visiting any cell in Items and then
+	// returning to Start.
+	GoalCollectReturn GoalMode = "collect-return"
+	// GoalCollectAll wins by visiting every cell in Items before reaching
+	// Goal.
+	GoalCollectAll GoalMode = "collect-all"
 )
 
+const defaultGoalMode = GoalSingle
+
+var goalModes = map[GoalMode]bool{
+	GoalSingle:        true,
+	GoalMulti:         true,
+	GoalCollectReturn: true,
+	GoalCollectAll:    true,
+}
+
+// resolveGoalMode validates mode against goalModes, falling back to
+// defaultGoalMode for "" or an unrecognized value.
+func resolveGoalMode(mode GoalMode) GoalMode {
+	if goalModes[mode] {
+		return mode
+	}
+	return defaultGoalMode
+}
+
 type Position struct {
 	X int `json:"x"`
 	Y int `json:"y"`
@@ -69,6 +121,9 @@ type Exploration struct {
 	FoundGoal        bool       `json:"found_goal"`
 	FixedColorIndex  int        `json:"fixed_color_index"`
 	Generation       int        `json:"generation"`
+	// FScore is set only by a simulated A* Pathfinder run (see
+	// runPathfindingSimulation) - nil for every caller-driven exploration.
+	FScore *float64 `json:"f_score,omitempty"`
 }
 
 func NewExploration(id string, startPos, currentPos Position, parentID *string, generation int, fixedColorIndex int) *Exploration {
@@ -108,31 +163,77 @@ type Game struct {
 	Width, Height            int
 	Start, Goal              Position
 	Explorations             map[string]*Exploration
-	GlobalVisitedPositions   map[Position]bool
+	GlobalVisitedPositions   *kdTree
 	GoalFound                bool
 	WinningExploration       *string
 	NextExplorationID        int
 	TotalSteps               int
 	MaxConcurrentExplorations int
 	ShowOnlyWinner           bool
+	SnapshotVersion          int
+
+	// mu guards Explorations, GlobalVisitedPositions, MoveLog, and the other
+	// fields above against concurrent handlers. Handlers take the lock;
+	// internal Game methods assume it's already held by their caller.
+	mu sync.RWMutex
+
+	// events carries this game's exploration events to its own /game/{id}/ws
+	// subscribers, independent of every other game's event stream.
+	events *eventBus
+
+	// deltaEvents carries this game's per-exploration position deltas to its
+	// own /game/{id}/ws/explorations subscribers, so a canvas renderer can
+	// draw the tree incrementally instead of polling exploration-tree or
+	// render for a full repaint.
+	deltaEvents *deltaBus
+
+	// Algorithm is the name of the MazeGenerator that built Maze, so a
+	// saved game can be regenerated with the same shape of maze later.
+	Algorithm string
+
+	// GoalMode selects which win condition checkWinCondition enforces.
+	// Goals holds every winning cell under GoalMulti (Goal is always
+	// Goals[0], kept for the single-goal renderers and APIs). Items holds
+	// the cells GoalCollectReturn/GoalCollectAll require an exploration to
+	// visit.
+	GoalMode GoalMode
+	Goals    []Position
+	Items    []Position
+
+	// MoveLog records every accepted move in order, so GET /game/{id}/move/{n}
+	// can answer for a specific one, POST /replay can stream them back over
+	// /ws, and POST /undo can pop and roll back the most recent.
+	MoveLog []MoveLogEntry
+
+	// Pathfinder is the algorithm that steps this game's Explorations. Only
+	// the scratch game runPathfindingSimulation builds for a ?algorithm=
+	// render is ever stepped; a live game's Pathfinder is set but never
+	// run, so it never touches the caller-driven Explorations above.
+	Pathfinder Pathfinder
 }
 
-func NewGame(width, height int, seed int64) *Game {
-	rand.Seed(seed)
-	
+// NewGame builds a new maze of the given size, generation algorithm, and
+// goal mode. algo is one of the mazeGenerators keys and mode one of
+// goalModes; either "" falls back to its default.
+func NewGame(width, height int, seed int64, algo string, mode GoalMode) *Game {
 	game := &Game{
 		Width:                     width,
 		Height:                    height,
 		Explorations:              make(map[string]*Exploration),
-		GlobalVisitedPositions:    make(map[Position]bool),
+		GlobalVisitedPositions:    newKDTree(),
 		GoalFound:                 false,
 		NextExplorationID:         0,
 		TotalSteps:                0,
 		MaxConcurrentExplorations: 0,
 		ShowOnlyWinner:            false,
+		events:                    newEventBus(500),
+		deltaEvents:               newDeltaBus(500),
+		Pathfinder:                pathfinders[defaultPathfindingAlgorithm](),
 	}
 
-	game.generateMaze()
+	rng := rand.New(rand.NewSource(seed))
+	game.generateMaze(rng, algo)
+	game.placeGoalModeCells(rng, mode)
 	return game
 }
 
@@ -159,15 +260,27 @@ func NewGameFromJSON(jsonFile string) (*Game, error) {
 		Start:                     treeData.Metadata.Start,
 		Goal:                      treeData.Metadata.Goal,
 		Explorations:              make(map[string]*Exploration),
-		GlobalVisitedPositions:    make(map[Position]bool),
+		GlobalVisitedPositions:    newKDTree(),
 		GoalFound:                 treeData.Metadata.GoalFound,
 		WinningExploration:        treeData.Metadata.WinningSegment,
 		NextExplorationID:         treeData.Metadata.NextID,
 		TotalSteps:                treeData.Metadata.TotalSteps,
 		MaxConcurrentExplorations: treeData.Metadata.MaxConcurrentSegments,
 		ShowOnlyWinner:            treeData.Metadata.ShowOnlyWinner,
+		Algorithm:                 treeData.Metadata.Algorithm,
+		GoalMode:                  resolveGoalMode(treeData.Metadata.GoalMode),
+		Goals:                     treeData.Metadata.Goals,
+		Items:                     treeData.Metadata.Items,
+		MoveLog:                   treeData.MoveLog,
+		events:                    newEventBus(500),
+		deltaEvents:               newDeltaBus(500),
+		Pathfinder:                pathfinders[defaultPathfindingAlgorithm](),
 	}
-	
+	if len(game.Goals) == 0 {
+		// Saved before GoalMode existed - fall back to the single Goal.
+		game.Goals = []Position{game.Goal}
+	}
+
 	// Convert maze from [][]int to [][]CellType
 	game.Maze = make([][]CellType, game.Height)
 	for y := 0; y < game.Height; y++ {
@@ -184,11 +297,11 @@ func NewGameFromJSON(jsonFile string) (*Game, error) {
 	
 	// Load global visited positions
 	for _, pos := range treeData.GlobalVisitedPositions {
-		game.GlobalVisitedPositions[pos] = true
+		game.GlobalVisitedPositions.Insert(pos)
 	}
 	
 	fmt.Printf("✅ Maze loaded: %dx%d, %d segments, %d visited positions\n", 
-		game.Width, game.Height, len(game.Explorations), len(game.GlobalVisitedPositions))
+		game.Width, game.Height, len(game.Explorations), game.GlobalVisitedPositions.Len())
 		
 	return game, nil
 }
@@ -279,13 +392,31 @@ func (g *Game) getExplorationDisplayColorAndStyle(exp *Exploration) (color.RGBA,
 	return segmentColors[baseColorIndex], 2, 0.9, 5
 }
 
+// MazeGridResponse is the full static maze layout (dimensions, cell types,
+// start/goal), used by clients that need to render the maze themselves
+// instead of requesting a server-generated image.
+type MazeGridResponse struct {
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Start  Position   `json:"start"`
+	Goal   Position   `json:"goal"`
+	Cells  [][]int    `json:"cells"`
+}
+
 type MazeStatusResponse struct {
-	IsExplored           bool            `json:"is_explored"`
-	IsJunction           bool            `json:"is_junction"`
-	AvailableDirections  []Direction     `json:"available_directions"`
-	AvailableMoves       []AvailableMove `json:"available_moves"`
-	IsGoal               bool            `json:"is_goal"`
-	GoalReachedByAny     bool            `json:"goal_reached_by_any"`
+	IsExplored          bool            `json:"is_explored"`
+	IsJunction          bool            `json:"is_junction"`
+	AvailableDirections []Direction     `json:"available_directions"`
+	AvailableMoves      []AvailableMove `json:"available_moves"`
+	IsGoal              bool            `json:"is_goal"`
+	GoalReachedByAny    bool            `json:"goal_reached_by_any"`
+	// ItemsRemaining is how many of g.Items the querying exploration still
+	// hasn't visited (the full item count if the query isn't tied to a
+	// specific exploration); 0 under every GoalMode that doesn't use Items.
+	ItemsRemaining int `json:"items_remaining"`
+	// MustReturnToStart is true under GoalCollectReturn, where reaching an
+	// item isn't enough - the exploration has to make it back to Start.
+	MustReturnToStart bool `json:"must_return_to_start"`
 }
 
 type MoveRequest struct {
@@ -299,6 +430,15 @@ type MoveResponse struct {
 	NewStatus string `json:"new_status"`
 }
 
+// MoveLogEntry is one accepted move, as recorded in Game.MoveLog.
+type MoveLogEntry struct {
+	ExplorationName string    `json:"exploration_name"`
+	From            Position  `json:"from"`
+	To              Position  `json:"to"`
+	Timestamp       time.Time `json:"timestamp"`
+	NewStatus       string    `json:"new_status"`
+}
+
 type ExplorationTreeResponse struct {
 	Explorations map[string]*Exploration `json:"explorations"`
 	GlobalStats  struct {
@@ -315,6 +455,7 @@ type PathSegmentTree struct {
 	Maze                     [][]int                    `json:"maze"`
 	Segments                 map[string]*Exploration    `json:"segments"`
 	GlobalVisitedPositions   []Position                 `json:"global_visited_positions"`
+	MoveLog                  []MoveLogEntry             `json:"move_log"`
 }
 
 type Metadata struct {
@@ -328,17 +469,275 @@ type Metadata struct {
 	TotalSteps                int      `json:"total_steps"`
 	MaxConcurrentSegments     int      `json:"max_concurrent_segments"`
 	NextID                    int      `json:"next_id"`
+	Algorithm                 string   `json:"algorithm"`
+	GoalMode                  GoalMode   `json:"goal_mode"`
+	Goals                     []Position `json:"goals"`
+	Items                     []Position `json:"items"`
+}
+
+// API holds every active Game, keyed by ID, so one server process can host
+// concurrent tournaments or A/B comparisons of exploration strategies
+// against independent (or identically-seeded) mazes.
+type API struct {
+	mu     sync.RWMutex
+	games  map[int]*Game
+	nextID int
+}
+
+func NewAPI() *API {
+	return &API{games: make(map[int]*Game)}
+}
+
+// addGame registers g under a fresh ID and returns it.
+func (a *API) addGame(g *Game) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := a.nextID
+	a.nextID++
+	a.games[id] = g
+	return id
+}
+
+func (a *API) getGame(id int) (*Game, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	g, ok := a.games[id]
+	return g, ok
+}
+
+// gameSummary is one entry of the GET /games listing.
+type gameSummary struct {
+	ID                int  `json:"id"`
+	Width             int  `json:"width"`
+	Height            int  `json:"height"`
+	TotalExplorations int  `json:"total_explorations"`
+	GoalFound         bool `json:"goal_found"`
+}
+
+func (a *API) list() []gameSummary {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	out := make([]gameSummary, 0, len(a.games))
+	for id, g := range a.games {
+		g.mu.RLock()
+		out = append(out, gameSummary{
+			ID:                id,
+			Width:             g.Width,
+			Height:            g.Height,
+			TotalExplorations: len(g.Explorations),
+			GoalFound:         g.GoalFound,
+		})
+		g.mu.RUnlock()
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+var api *API
+
+// EventType enumerates the incremental exploration events pushed over /ws.
+type EventType string
+
+const (
+	EventExplorationCreated EventType = "exploration_created"
+	EventMoved              EventType = "moved"
+	EventJunction           EventType = "junction"
+	EventDeadEnd            EventType = "dead_end"
+	EventGoalReached        EventType = "goal_reached"
+	EventReset              EventType = "reset"
+)
+
+// Event is one JSON frame pushed to /ws subscribers. ID is assigned by the
+// eventBus on publish so reconnecting clients can replay from it via
+// last_event_id.
+type Event struct {
+	ID              int64     `json:"id"`
+	Type            EventType `json:"type"`
+	ExplorationName string    `json:"exploration_name,omitempty"`
+	Position        *Position `json:"position,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// eventBus fans incoming events out to every subscribed /ws connection and
+// keeps a bounded ring buffer so a reconnecting client can catch up from the
+// last event ID it saw instead of missing updates.
+type eventBus struct {
+	mu          sync.Mutex
+	nextID      int64
+	ring        []Event
+	ringSize    int
+	subscribers map[chan Event]struct{}
+}
+
+func newEventBus(ringSize int) *eventBus {
+	return &eventBus{
+		ringSize:    ringSize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+func (b *eventBus) publish(evt Event) {
+	b.mu.Lock()
+	b.nextID++
+	evt.ID = b.nextID
+	b.ring = append(b.ring, evt)
+	if len(b.ring) > b.ringSize {
+		b.ring = b.ring[len(b.ring)-b.ringSize:]
+	}
+	subs := make([]chan Event, 0, len(b.subscribers))
+	for ch := range b.subscribers {
+		subs = append(subs, ch)
+	}
+	b.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- evt:
+		default: // slow subscriber; drop rather than block the publisher
+		}
+	}
+}
+
+func (b *eventBus) subscribe() (ch chan Event, unsubscribe func()) {
+	ch = make(chan Event, 32)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	// unsubscribe deliberately never closes ch: publish may already have
+	// read ch out of the subscriber set and be about to send on it outside
+	// b.mu, and a send racing a close panics (the "slow subscriber" default
+	// case in publish only guards a full buffer, not a closed channel). Once
+	// ch is out of subscribers nothing sends to it again, so it's simply
+	// left for the garbage collector instead.
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+// replaySince returns every buffered event with ID greater than lastEventID,
+// for a client's catch-up replay on reconnect.
+func (b *eventBus) replaySince(lastEventID int64) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []Event
+	for _, evt := range b.ring {
+		if evt.ID > lastEventID {
+			out = append(out, evt)
+		}
+	}
+	return out
+}
+
+// ExplorationDelta is one incremental update pushed to /ws/explorations
+// subscribers: just the positions a single move appended to an
+// exploration's PathPositions, plus any state transition, instead of
+// handleWebSocket's coarser Event - a canvas renderer can apply these
+// directly without ever re-fetching the full exploration tree or a PNG.
+type ExplorationDelta struct {
+	SeqID         int64      `json:"seq_id"`
+	ExplorationID string     `json:"exploration_id,omitempty"`
+	NewPositions  []Position `json:"new_positions,omitempty"`
+	IsActive      bool       `json:"is_active,omitempty"`
+	FoundGoal     bool       `json:"found_goal,omitempty"`
+	Spawned       bool       `json:"spawned,omitempty"`
+	Terminated    bool       `json:"terminated,omitempty"`
+	// Reset marks a frame with no exploration of its own: the game was just
+	// reset, so the client should drop every exploration it's tracking.
+	Reset     bool      `json:"reset,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// deltaBus fans ExplorationDelta updates out to /ws/explorations
+// subscribers, with the same bounded-ring replay-from-last-id behavior as
+// eventBus.
+type deltaBus struct {
+	mu          sync.Mutex
+	nextID      int64
+	ring        []ExplorationDelta
+	ringSize    int
+	subscribers map[chan ExplorationDelta]struct{}
+}
+
+func newDeltaBus(ringSize int) *deltaBus {
+	return &deltaBus{
+		ringSize:    ringSize,
+		subscribers: make(map[chan ExplorationDelta]struct{}),
+	}
+}
+
+func (b *deltaBus) publish(delta ExplorationDelta) {
+	b.mu.Lock()
+	b.nextID++
+	delta.SeqID = b.nextID
+	b.ring = append(b.ring, delta)
+	if len(b.ring) > b.ringSize {
+		b.ring = b.ring[len(b.ring)-b.ringSize:]
+	}
+	subs := make([]chan ExplorationDelta, 0, len(b.subscribers))
+	for ch := range b.subscribers {
+		subs = append(subs, ch)
+	}
+	b.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- delta:
+		default: // slow subscriber; drop rather than block the publisher
+		}
+	}
+}
+
+func (b *deltaBus) subscribe() (ch chan ExplorationDelta, unsubscribe func()) {
+	ch = make(chan ExplorationDelta, 32)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	// See eventBus.subscribe's unsubscribe: never close ch, since publish
+	// may have already snapshotted it out of subscribers and be about to
+	// send outside b.mu - a send racing a close panics regardless of the
+	// buffered-send default case. It's just left for the GC once dropped.
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
 }
 
-var game *Game
+// replaySince returns every buffered delta with SeqID greater than
+// lastSeqID, for a client's catch-up replay on reconnect.
+func (b *deltaBus) replaySince(lastSeqID int64) []ExplorationDelta {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []ExplorationDelta
+	for _, delta := range b.ring {
+		if delta.SeqID > lastSeqID {
+			out = append(out, delta)
+		}
+	}
+	return out
+}
 
 func main() {
 	// Command line flags
 	host := flag.String("host", "localhost", "Server host")
 	port := flag.String("port", "8079", "Server port")
+	algo := flag.String("algo", defaultMazeAlgorithm, "Maze generation algorithm (recursive-backtracker, prim, kruskal, wilson, binary-tree, eller)")
+	goalMode := flag.String("goal-mode", string(defaultGoalMode), "Win condition (single, multi-goal, collect-return, collect-all)")
+	openViewer := flag.Bool("viewer", false, "Open an interactive Ebiten window onto game #0 instead of only serving HTTP")
 	flag.Parse()
 
-	// Try to load from JSON first, otherwise generate new maze
+	api = NewAPI()
+
+	// Try to load from JSON first, otherwise generate new maze. Either way
+	// it becomes game #0, so a client that hasn't been updated to the
+	// multi-game API yet can still reach it at /game/0/...
+	var initialGame *Game
 	jsonFile := "pathsegment_tree.json"
 	if _, err := os.Stat(jsonFile); err == nil {
 		fmt.Printf("📂 Found existing maze file: %s\n", jsonFile)
@@ -346,37 +745,184 @@ func main() {
 		if err != nil {
 			fmt.Printf("⚠️  Failed to load from JSON: %v\n", err)
 			fmt.Println("🎲 Generating new maze instead...")
-			game = NewGame(31, 31, 42)
+			initialGame = NewGame(31, 31, 42, *algo, GoalMode(*goalMode))
 		} else {
-			game = loadedGame
+			initialGame = loadedGame
 			fmt.Println("🔄 Loaded existing maze and exploration state")
 		}
 	} else {
 		fmt.Printf("🎲 No existing maze found, generating new maze...\n")
-		game = NewGame(31, 31, 42)
+		initialGame = NewGame(31, 31, 42, *algo, GoalMode(*goalMode))
 		fmt.Println("✨ Generated new maze")
 	}
+	initialID := api.addGame(initialGame)
 
-	http.HandleFunc("/maze-status", handleMazeStatus)
-	http.HandleFunc("/exploration-status", handleExplorationStatus)
-	http.HandleFunc("/move", handleMove)
-	http.HandleFunc("/exploration-tree", handleExplorationTree)
-	http.HandleFunc("/reset", handleReset)
-	http.HandleFunc("/render", handleRender)
+	http.HandleFunc("/game", handleCreateGame)
+	http.HandleFunc("/games", handleListGames)
+	http.HandleFunc("/game/", handleGameRoute)
+	http.HandleFunc("/manifest.json", handleManifest)
+	http.HandleFunc("/service-worker.js", handleServiceWorker)
+	http.HandleFunc("/web", handleWeb)
 
 	addr := *host + ":" + *port
 	fmt.Printf("🎮 Maze Game Server starting on %s\n", addr)
-	fmt.Printf("📐 Maze size: %dx%d\n", game.Width, game.Height)
-	fmt.Printf("📍 Start: (%d, %d)\n", game.Start.X, game.Start.Y)
-	fmt.Printf("🎯 Goal: (%d, %d)\n", game.Goal.X, game.Goal.Y)
+	fmt.Printf("📐 Game #%d size: %dx%d\n", initialID, initialGame.Width, initialGame.Height)
+	fmt.Printf("📍 Start: (%d, %d)\n", initialGame.Start.X, initialGame.Start.Y)
+	fmt.Printf("🎯 Goal: (%d, %d)\n", initialGame.Goal.X, initialGame.Goal.Y)
 	fmt.Println("🖼️  Render: SVG images returned to client")
 	fmt.Println("🚀 Ready for exploration commands!")
-	fmt.Printf("🌐 Web viewer available at: http://%s/web\n", addr)
+	fmt.Printf("🌐 Web viewer available at: http://%s/web?id=%d\n", addr, initialID)
+
+	if *openViewer {
+		// ebiten.RunGame must run on the OS main thread, so the HTTP server
+		// moves to a goroutine and the interactive window takes over main().
+		go func() {
+			log.Println(http.ListenAndServe(addr, nil))
+		}()
+		fmt.Println("🖥️  Opening interactive viewer for game #0...")
+		if err := launchViewer(initialGame); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	log.Fatal(http.ListenAndServe(addr, nil))
 }
 
-func handleMazeStatus(w http.ResponseWriter, r *http.Request) {
+// createGameRequest is the POST /game body. Any field left zero falls back
+// to the same defaults main() uses for the initial game. The generation
+// algorithm and goal mode aren't part of the body - pass them as ?algo=
+// and ?goal_mode= on the request URL, same as -algo/-goal-mode on the
+// command line.
+type createGameRequest struct {
+	Width  int   `json:"width"`
+	Height int   `json:"height"`
+	Seed   int64 `json:"seed"`
+}
+
+type createGameResponse struct {
+	ID        int      `json:"id"`
+	Width     int      `json:"width"`
+	Height    int      `json:"height"`
+	Algorithm string   `json:"algorithm"`
+	GoalMode  GoalMode `json:"goal_mode"`
+}
+
+func handleCreateGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req createGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Width == 0 {
+		req.Width = 31
+	}
+	if req.Height == 0 {
+		req.Height = 31
+	}
+	if req.Seed == 0 {
+		req.Seed = time.Now().UnixNano()
+	}
+
+	g := NewGame(req.Width, req.Height, req.Seed, r.URL.Query().Get("algo"), GoalMode(r.URL.Query().Get("goal_mode")))
+	id := api.addGame(g)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(createGameResponse{ID: id, Width: g.Width, Height: g.Height, Algorithm: g.Algorithm, GoalMode: g.GoalMode})
+}
+
+func handleListGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.list())
+}
+
+// gameFromPath parses "/game/{id}/{action}", resolving the Game the rest of
+// the path acts on. It writes the error response itself and returns
+// ok=false on a malformed path or unknown id.
+func gameFromPath(w http.ResponseWriter, r *http.Request) (game *Game, action string, ok bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/game/")
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		http.Error(w, "Invalid game path", http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid game id", http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	g, exists := api.getGame(id)
+	if !exists {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return nil, "", false
+	}
+
+	return g, parts[1], true
+}
+
+// handleGameRoute dispatches every /game/{id}/{action} request to the
+// handler for action, with the resolved Game already in hand.
+func handleGameRoute(w http.ResponseWriter, r *http.Request) {
+	game, action, ok := gameFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if strings.HasPrefix(action, "move/") {
+		handleMoveHistory(w, r, game, strings.TrimPrefix(action, "move/"))
+		return
+	}
+
+	switch action {
+	case "maze-status":
+		handleMazeStatus(w, r, game)
+	case "exploration-status":
+		handleExplorationStatus(w, r, game)
+	case "move":
+		handleMove(w, r, game)
+	case "exploration-tree":
+		handleExplorationTree(w, r, game)
+	case "reset":
+		handleReset(w, r, game)
+	case "render":
+		handleRender(w, r, game)
+	case "render-gif":
+		handleRenderGIF(w, r, game)
+	case "maze-grid":
+		handleMazeGrid(w, r, game)
+	case "snapshot.json":
+		handleSnapshot(w, r, game)
+	case "ws":
+		handleWebSocket(w, r, game)
+	case "ws/explorations":
+		handleExplorationWS(w, r, game)
+	case "nearest-unvisited":
+		handleNearestUnvisited(w, r, game)
+	case "visited-in-radius":
+		handleVisitedInRadius(w, r, game)
+	case "replay":
+		handleReplay(w, r, game)
+	case "undo":
+		handleUndo(w, r, game)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func handleMazeStatus(w http.ResponseWriter, r *http.Request, game *Game) {
 	if r.Method != "GET" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -386,13 +932,15 @@ func handleMazeStatus(w http.ResponseWriter, r *http.Request) {
 	y, _ := strconv.Atoi(r.URL.Query().Get("y"))
 	pos := Position{x, y}
 
-	response := game.getMazeStatus(pos)
+	game.mu.RLock()
+	response := game.getMazeStatus(pos, nil)
+	game.mu.RUnlock()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func handleExplorationStatus(w http.ResponseWriter, r *http.Request) {
+func handleExplorationStatus(w http.ResponseWriter, r *http.Request, game *Game) {
 	if r.Method != "GET" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -404,20 +952,22 @@ func handleExplorationStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	game.mu.RLock()
 	exploration, exists := game.Explorations[explorationName]
 	if !exists {
+		game.mu.RUnlock()
 		http.Error(w, "Exploration not found", http.StatusNotFound)
 		return
 	}
-
 	pos := exploration.CurrentPosition
-	response := game.getMazeStatus(pos)
+	response := game.getMazeStatus(pos, exploration.PathPositions)
+	game.mu.RUnlock()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func handleMove(w http.ResponseWriter, r *http.Request) {
+func handleMove(w http.ResponseWriter, r *http.Request, game *Game) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -429,171 +979,1839 @@ func handleMove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	game.mu.Lock()
+	_, existedBefore := game.Explorations[req.ExplorationName]
 	response := game.moveExploration(req.ExplorationName, req.NextPosition)
+	if response.Success {
+		game.SnapshotVersion++
+	}
+	game.mu.Unlock()
+
+	if response.Success {
+		publishMoveEvents(game, req, response, existedBefore)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func handleExplorationTree(w http.ResponseWriter, r *http.Request) {
+// publishMoveEvents maps a successful MoveResponse onto the /ws event types
+// it represents: a brand-new exploration always fires exploration_created,
+// and its outcome (junction/dead_end/goal_reached/plain move) fires
+// alongside it.
+func publishMoveEvents(game *Game, req MoveRequest, response MoveResponse, existedBefore bool) {
+	pos := req.NextPosition
+	now := time.Now()
+
+	if !existedBefore {
+		game.events.publish(Event{Type: EventExplorationCreated, ExplorationName: req.ExplorationName, Position: &pos, Timestamp: now})
+	}
+
+	switch response.NewStatus {
+	case "goal_reached":
+		game.events.publish(Event{Type: EventGoalReached, ExplorationName: req.ExplorationName, Position: &pos, Timestamp: now})
+	case "junction":
+		game.events.publish(Event{Type: EventJunction, ExplorationName: req.ExplorationName, Position: &pos, Timestamp: now})
+	case "dead_end":
+		game.events.publish(Event{Type: EventDeadEnd, ExplorationName: req.ExplorationName, Position: &pos, Timestamp: now})
+	case "continue":
+		if existedBefore {
+			game.events.publish(Event{Type: EventMoved, ExplorationName: req.ExplorationName, Position: &pos, Timestamp: now})
+		}
+	}
+
+	terminated := response.NewStatus == "dead_end" || response.NewStatus == "goal_reached"
+	game.deltaEvents.publish(ExplorationDelta{
+		ExplorationID: req.ExplorationName,
+		NewPositions:  []Position{pos},
+		IsActive:      !terminated,
+		FoundGoal:     response.NewStatus == "goal_reached",
+		Spawned:       !existedBefore,
+		Terminated:    terminated,
+		Timestamp:     now,
+	})
+}
+
+func handleExplorationTree(w http.ResponseWriter, r *http.Request, game *Game) {
 	if r.Method != "GET" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	game.mu.RLock()
 	response := game.getExplorationTree()
+	game.mu.RUnlock()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func handleReset(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "POST" {
+func handleMazeGrid(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "GET" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Reset game state
-	game.Explorations = make(map[string]*Exploration)
-	game.GlobalVisitedPositions = make(map[Position]bool)
-	game.GoalFound = false
-	game.WinningExploration = nil
-	game.NextExplorationID = 0
-	game.TotalSteps = 0
-	game.MaxConcurrentExplorations = 0
-	game.ShowOnlyWinner = false
+	cells := make([][]int, game.Height)
+	for y := 0; y < game.Height; y++ {
+		cells[y] = make([]int, game.Width)
+		for x := 0; x < game.Width; x++ {
+			cells[y][x] = int(game.Maze[y][x])
+		}
+	}
+
+	response := MazeGridResponse{
+		Width:  game.Width,
+		Height: game.Height,
+		Start:  game.Start,
+		Goal:   game.Goal,
+		Cells:  cells,
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Game reset successfully",
-	})
+	json.NewEncoder(w).Encode(response)
 }
 
-func (g *Game) generateMaze() {
-	if g.Width%2 == 0 {
-		g.Width++
-	}
-	if g.Height%2 == 0 {
-		g.Height++
-	}
-
-	g.Maze = make([][]CellType, g.Height)
-	for y := 0; y < g.Height; y++ {
-		g.Maze[y] = make([]CellType, g.Width)
-		for x := 0; x < g.Width; x++ {
-			g.Maze[y][x] = WALL
-		}
-	}
+// NearestUnvisitedResponse answers GET /game/{id}/nearest-unvisited?x=&y=.
+// Found is false once every walkable cell has been visited.
+type NearestUnvisitedResponse struct {
+	Found    bool     `json:"found"`
+	Position Position `json:"position"`
+}
 
-	for y := 1; y < g.Height-1; y += 2 {
-		for x := 1; x < g.Width-1; x += 2 {
-			g.Maze[y][x] = PATH
-		}
+func handleNearestUnvisited(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
 
-	stack := []Position{{1, 1}}
-	visited := map[Position]bool{{1, 1}: true}
-	directions := []Direction{{0, -2}, {2, 0}, {0, 2}, {-2, 0}}
+	x, _ := strconv.Atoi(r.URL.Query().Get("x"))
+	y, _ := strconv.Atoi(r.URL.Query().Get("y"))
 
-	for len(stack) > 0 {
-		current := stack[len(stack)-1]
+	game.mu.RLock()
+	pos, found := game.nearestUnvisited(Position{x, y})
+	game.mu.RUnlock()
 
-		var neighbors []Position
-		for _, dir := range directions {
-			next := Position{current.X + dir.X, current.Y + dir.Y}
-			if next.X >= 1 && next.X < g.Width-1 && 
-			   next.Y >= 1 && next.Y < g.Height-1 && 
-			   !visited[next] {
-				neighbors = append(neighbors, next)
-			}
-		}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(NearestUnvisitedResponse{Found: found, Position: pos})
+}
 
-		if len(neighbors) > 0 {
-			next := neighbors[rand.Intn(len(neighbors))]
-			visited[next] = true
+// VisitedInRadiusResponse answers GET /game/{id}/visited-in-radius?x=&y=&radius=,
+// for clients rendering a heatmap of explored territory around a point.
+type VisitedInRadiusResponse struct {
+	Center  Position   `json:"center"`
+	Radius  int        `json:"radius"`
+	Visited []Position `json:"visited"`
+}
 
-			wallX := current.X + (next.X-current.X)/2
-			wallY := current.Y + (next.Y-current.Y)/2
-			g.Maze[wallY][wallX] = PATH
+func handleVisitedInRadius(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-			stack = append(stack, next)
-		} else {
-			stack = stack[:len(stack)-1]
-		}
+	x, _ := strconv.Atoi(r.URL.Query().Get("x"))
+	y, _ := strconv.Atoi(r.URL.Query().Get("y"))
+	radius, err := strconv.Atoi(r.URL.Query().Get("radius"))
+	if err != nil || radius < 0 {
+		radius = 5
 	}
+	center := Position{x, y}
 
-	for i := 0; i < g.Width*g.Height/30; i++ {
-		x := 2 + rand.Intn((g.Width-4)/2)*2
-		y := 2 + rand.Intn((g.Height-4)/2)*2
+	game.mu.RLock()
+	visited := game.GlobalVisitedPositions.VisitedInRadius(center, radius)
+	game.mu.RUnlock()
 
-		for _, dir := range []Direction{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
-			nx, ny := x+dir.X, y+dir.Y
-			if nx >= 0 && nx < g.Width && ny >= 0 && ny < g.Height && 
-			   g.Maze[ny][nx] == PATH {
-				g.Maze[y][x] = PATH
-				break
-			}
-		}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(VisitedInRadiusResponse{Center: center, Radius: radius, Visited: visited})
+}
+
+// handleMoveHistory answers GET /game/{id}/move/{n} with the nth
+// (0-indexed) entry of MoveLog.
+func handleMoveHistory(w http.ResponseWriter, r *http.Request, game *Game, n string) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
 
-	g.Start = Position{1, 1}
-	g.Maze[1][1] = START
+	idx, err := strconv.Atoi(n)
+	if err != nil {
+		http.Error(w, "Invalid move number", http.StatusBadRequest)
+		return
+	}
 
-	maxDist := 0
-	bestGoal := Position{g.Width - 2, g.Height - 2}
-	for y := 1; y < g.Height-1; y += 2 {
-		for x := 1; x < g.Width-1; x += 2 {
-			if g.Maze[y][x] == PATH {
-				dist := abs(x-1) + abs(y-1)
-				if dist > maxDist {
-					maxDist = dist
-					bestGoal = Position{x, y}
-				}
-			}
-		}
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+
+	if idx < 0 || idx >= len(game.MoveLog) {
+		http.Error(w, "Move not found", http.StatusNotFound)
+		return
 	}
 
-	g.Goal = bestGoal
-	g.Maze[bestGoal.Y][bestGoal.X] = GOAL
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(game.MoveLog[idx])
 }
 
-func abs(x int) int {
-	if x < 0 {
-		return -x
+// moveLogEventType maps a MoveLogEntry's NewStatus back to the Event type
+// publishMoveEvents would have fired for it at the time, so a replay looks
+// the same on the /ws channel as the original run did.
+func moveLogEventType(status string) EventType {
+	switch status {
+	case "goal_reached":
+		return EventGoalReached
+	case "junction":
+		return EventJunction
+	case "dead_end":
+		return EventDeadEnd
+	default:
+		return EventMoved
 	}
-	return x
 }
 
-func (g *Game) isWalkable(pos Position) bool {
-	if pos.X < 0 || pos.X >= g.Width || pos.Y < 0 || pos.Y >= g.Height {
-		return false
+// handleReplay re-publishes every MoveLog entry, in order, onto the game's
+// /ws event bus, so a client already watching that connection sees the run
+// again. speed is moves per second (default 10); the handler blocks for the
+// duration of playback.
+func handleReplay(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
-	return g.Maze[pos.Y][pos.X] != WALL
-}
 
-func (g *Game) isCollision(pos Position) bool {
-	return g.GlobalVisitedPositions[pos]
-}
+	speed, err := strconv.Atoi(r.URL.Query().Get("speed"))
+	if err != nil || speed <= 0 {
+		speed = 10
+	}
+	interval := time.Second / time.Duration(speed)
 
-func (g *Game) getValidDirections(pos Position) []Direction {
-	var valid []Direction
-	directions := []Direction{UP, DOWN, LEFT, RIGHT}
+	game.mu.RLock()
+	entries := make([]MoveLogEntry, len(game.MoveLog))
+	copy(entries, game.MoveLog)
+	game.mu.RUnlock()
 
-	for _, dir := range directions {
-		newPos := pos.Add(dir)
-		if g.isWalkable(newPos) && !g.isCollision(newPos) {
-			valid = append(valid, dir)
+	for i, entry := range entries {
+		pos := entry.To
+		now := time.Now()
+		game.events.publish(Event{
+			Type:            moveLogEventType(entry.NewStatus),
+			ExplorationName: entry.ExplorationName,
+			Position:        &pos,
+			Timestamp:       now,
+		})
+		terminated := entry.NewStatus == "dead_end" || entry.NewStatus == "goal_reached"
+		game.deltaEvents.publish(ExplorationDelta{
+			ExplorationID: entry.ExplorationName,
+			NewPositions:  []Position{pos},
+			IsActive:      !terminated,
+			FoundGoal:     entry.NewStatus == "goal_reached",
+			Terminated:    terminated,
+			Timestamp:     now,
+		})
+		if i < len(entries)-1 {
+			time.Sleep(interval)
 		}
 	}
-	return valid
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"replayed": len(entries),
+	})
+}
+
+// handleUndo pops the most recent MoveLog entry and rolls back the game
+// state it caused - see Game.popLastMove.
+func handleUndo(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	game.mu.Lock()
+	ok := game.popLastMove()
+	if ok {
+		game.SnapshotVersion++
+	}
+	game.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": ok,
+	})
+}
+
+// webManifest is served at /manifest.json so a browser can install the web
+// viewer as a standalone Progressive Web App.
+type webManifest struct {
+	Name            string         `json:"name"`
+	ShortName       string         `json:"short_name"`
+	StartURL        string         `json:"start_url"`
+	Display         string         `json:"display"`
+	BackgroundColor string         `json:"background_color"`
+	ThemeColor      string         `json:"theme_color"`
+	Icons           []manifestIcon `json:"icons"`
+}
+
+type manifestIcon struct {
+	Src   string `json:"src"`
+	Sizes string `json:"sizes"`
+	Type  string `json:"type"`
+}
+
+func handleManifest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	manifest := webManifest{
+		Name:            "Maze Exploration Viewer",
+		ShortName:       "MazeViewer",
+		StartURL:        "/web?id=0",
+		Display:         "standalone",
+		BackgroundColor: "#FFFFFF",
+		ThemeColor:      "#FF6D00",
+		Icons:           []manifestIcon{},
+	}
+
+	w.Header().Set("Content-Type", "application/manifest+json")
+	json.NewEncoder(w).Encode(manifest)
+}
+
+// webViewerPage is served at GET /web?id={gameID} - a canvas renderer that
+// polls that game's /game/{id}/snapshot.json (the same bundle
+// NewGameFromJSON loads from, see toPathSegmentTree) once a second and
+// redraws the maze and every exploration's current position from it.
+const webViewerPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Maze Viewer</title>
+<link rel="manifest" href="/manifest.json">
+<style>body{background:#111;color:#eee;font-family:sans-serif} canvas{border:1px solid #444}</style>
+</head>
+<body>
+<h1>Maze Exploration Viewer</h1>
+<canvas id="maze" width="620" height="620"></canvas>
+<script>
+const gameID = new URLSearchParams(location.search).get('id') || '0';
+const canvas = document.getElementById('maze');
+const ctx = canvas.getContext('2d');
+const cellColors = {0: '#333', 1: '#111', 2: '#4CAF50', 3: '#F44336', 4: '#FFEB3B'};
+
+async function draw() {
+  const res = await fetch('/game/' + gameID + '/snapshot.json');
+  if (!res.ok) return;
+  const snap = await res.json();
+  const cell = Math.floor(Math.min(canvas.width / snap.metadata.width, canvas.height / snap.metadata.height));
+  for (let y = 0; y < snap.metadata.height; y++) {
+    for (let x = 0; x < snap.metadata.width; x++) {
+      ctx.fillStyle = cellColors[snap.maze[y][x]] || '#111';
+      ctx.fillRect(x * cell, y * cell, cell, cell);
+    }
+  }
+  ctx.fillStyle = '#00BCD4';
+  for (const id in snap.segments) {
+    const pos = snap.segments[id].current_position;
+    ctx.fillRect(pos.x * cell + cell * 0.25, pos.y * cell + cell * 0.25, cell * 0.5, cell * 0.5);
+  }
+}
+
+draw();
+setInterval(draw, 1000);
+if ('serviceWorker' in navigator) {
+  navigator.serviceWorker.register('/service-worker.js');
+}
+</script>
+</body>
+</html>
+`
+
+func handleWeb(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(webViewerPage))
+}
+
+// serviceWorkerScript precaches the web viewer shell and game #0's snapshot,
+// then falls back to whatever's cached when a fetch fails so the viewer
+// keeps showing exploration progress while disconnected. /render isn't
+// precached here - it's POST-only, and cache.addAll always fetches with GET.
+const serviceWorkerScript = `const CACHE_NAME = 'maze-pwa-v1';
+const PRECACHE_URLS = ['/web?id=0', '/manifest.json', '/game/0/snapshot.json'];
+
+self.addEventListener('install', (event) => {
+  event.waitUntil(
+    caches.open(CACHE_NAME).then((cache) => cache.addAll(PRECACHE_URLS))
+  );
+  self.skipWaiting();
+});
+
+self.addEventListener('activate', (event) => {
+  event.waitUntil(self.clients.claim());
+});
+
+self.addEventListener('fetch', (event) => {
+  event.respondWith(
+    fetch(event.request)
+      .then((response) => {
+        const copy = response.clone();
+        caches.open(CACHE_NAME).then((cache) => cache.put(event.request, copy));
+        return response;
+      })
+      .catch(() => caches.match(event.request))
+  );
+});
+`
+
+func handleServiceWorker(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/javascript")
+	w.Write([]byte(serviceWorkerScript))
+}
+
+// SnapshotResponse is the self-contained bundle served at /snapshot.json:
+// everything the web viewer needs to keep rendering exploration progress
+// offline, plus a monotonic version so a cached snapshot can be recognized
+// as stale once connectivity returns.
+type SnapshotResponse struct {
+	PathSegmentTree
+	Version int `json:"version"`
+}
+
+func handleSnapshot(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	game.mu.RLock()
+	snapshot := SnapshotResponse{
+		PathSegmentTree: game.toPathSegmentTree(),
+		Version:         game.SnapshotVersion,
+	}
+	game.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(snapshot)
+}
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// handleWebSocket upgrades to a long-lived connection that streams Event
+// frames as they're published by handleMove/handleReset. A reconnecting
+// client can pass ?last_event_id=N to replay anything it missed from the
+// event bus's ring buffer before switching to live updates.
+func handleWebSocket(w http.ResponseWriter, r *http.Request, game *Game) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("websocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	var lastEventID int64
+	if v := r.URL.Query().Get("last_event_id"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			lastEventID = n
+		}
+	}
+
+	for _, evt := range game.events.replaySince(lastEventID) {
+		if err := conn.WriteJSON(evt); err != nil {
+			return
+		}
+	}
+
+	ch, unsubscribe := game.events.subscribe()
+	defer unsubscribe()
+
+	for evt := range ch {
+		if err := conn.WriteJSON(evt); err != nil {
+			return
+		}
+	}
+}
+
+// handleExplorationWS upgrades to a long-lived connection that streams
+// ExplorationDelta frames - only the positions a move appended, plus any
+// state transition - as they're published by handleMove/handleReplay/
+// handleReset. Pass ?format=binary to receive the compact varint framing
+// instead of JSON, for large mazes where JSON overhead dominates. As with
+// handleWebSocket, ?last_event_id=N replays anything missed from the delta
+// bus's ring buffer before switching to live updates.
+func handleExplorationWS(w http.ResponseWriter, r *http.Request, game *Game) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("websocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	binaryFormat := r.URL.Query().Get("format") == "binary"
+
+	var lastEventID int64
+	if v := r.URL.Query().Get("last_event_id"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			lastEventID = n
+		}
+	}
+
+	send := func(delta ExplorationDelta) error {
+		if binaryFormat {
+			return conn.WriteMessage(websocket.BinaryMessage, encodeExplorationDeltaBinary(delta))
+		}
+		return conn.WriteJSON(delta)
+	}
+
+	for _, delta := range game.deltaEvents.replaySince(lastEventID) {
+		if err := send(delta); err != nil {
+			return
+		}
+	}
+
+	ch, unsubscribe := game.deltaEvents.subscribe()
+	defer unsubscribe()
+
+	for delta := range ch {
+		if err := send(delta); err != nil {
+			return
+		}
+	}
+}
+
+// encodeExplorationDeltaBinary packs delta into the compact binary frame
+// format ?format=binary clients ask for: a flags byte, the length-prefixed
+// exploration ID, then each new position as a zigzag varint (dx, dy) delta
+// from the previous one in the message - a typical single-cell move costs a
+// couple of bytes instead of a whole JSON object.
+func encodeExplorationDeltaBinary(delta ExplorationDelta) []byte {
+	var buf bytes.Buffer
+
+	var flags byte
+	if delta.IsActive {
+		flags |= 1 << 0
+	}
+	if delta.FoundGoal {
+		flags |= 1 << 1
+	}
+	if delta.Spawned {
+		flags |= 1 << 2
+	}
+	if delta.Terminated {
+		flags |= 1 << 3
+	}
+	if delta.Reset {
+		flags |= 1 << 4
+	}
+	buf.WriteByte(flags)
+
+	idBytes := []byte(delta.ExplorationID)
+	writeUvarint(&buf, uint64(len(idBytes)))
+	buf.Write(idBytes)
+
+	writeUvarint(&buf, uint64(len(delta.NewPositions)))
+	var prev Position
+	for _, pos := range delta.NewPositions {
+		writeVarint(&buf, pos.X-prev.X)
+		writeVarint(&buf, pos.Y-prev.Y)
+		prev = pos
+	}
+
+	return buf.Bytes()
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
+
+// writeVarint zigzag-encodes a signed delta so small moves in either
+// direction - almost every maze step is +-1 - cost a single byte.
+func writeVarint(buf *bytes.Buffer, v int) {
+	zigzag := uint64((int64(v) << 1) ^ (int64(v) >> 63))
+	writeUvarint(buf, zigzag)
+}
+
+func handleReset(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	game.mu.Lock()
+	// Reset game state
+	game.Explorations = make(map[string]*Exploration)
+	game.GlobalVisitedPositions = newKDTree()
+	game.GoalFound = false
+	game.WinningExploration = nil
+	game.NextExplorationID = 0
+	game.TotalSteps = 0
+	game.MaxConcurrentExplorations = 0
+	game.ShowOnlyWinner = false
+	game.MoveLog = nil
+	game.SnapshotVersion++
+	game.mu.Unlock()
+
+	now := time.Now()
+	game.events.publish(Event{Type: EventReset, Timestamp: now})
+	game.deltaEvents.publish(ExplorationDelta{Reset: true, Timestamp: now})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Game reset successfully",
+	})
+}
+
+// generateMaze rounds the game up to odd dimensions and delegates the
+// actual carving to the MazeGenerator named by algo, recording which one
+// it used so the result can be reproduced later.
+func (g *Game) generateMaze(rng *rand.Rand, algo string) {
+	if g.Width%2 == 0 {
+		g.Width++
+	}
+	if g.Height%2 == 0 {
+		g.Height++
+	}
+
+	gen, resolved := resolveMazeGenerator(algo)
+	g.Algorithm = resolved
+
+	g.Maze, g.Start, g.Goal = gen.Generate(g.Width, g.Height, rng)
+}
+
+// MazeGenerator carves a maze into a width*height grid and picks a
+// Start/Goal pair for it. Every implementation takes the same
+// (width, height, rng) input, so swapping algorithms via ?algo= or -algo
+// doesn't touch anything else about Game construction.
+type MazeGenerator interface {
+	Generate(width, height int, rng *rand.Rand) (maze [][]CellType, start, goal Position)
+}
+
+const defaultMazeAlgorithm = "recursive-backtracker"
+
+var mazeGenerators = map[string]MazeGenerator{
+	"recursive-backtracker": recursiveBacktrackerGenerator{},
+	"prim":                  primGenerator{},
+	"kruskal":               kruskalGenerator{},
+	"wilson":                wilsonGenerator{},
+	"binary-tree":           binaryTreeGenerator{},
+	"eller":                 ellerGenerator{},
+}
+
+// resolveMazeGenerator looks up algo in mazeGenerators, falling back to
+// defaultMazeAlgorithm for "" or an unrecognized name. It returns the
+// generator along with the name actually used, so callers can record it.
+func resolveMazeGenerator(algo string) (MazeGenerator, string) {
+	if gen, ok := mazeGenerators[algo]; ok {
+		return gen, algo
+	}
+	return mazeGenerators[defaultMazeAlgorithm], defaultMazeAlgorithm
+}
+
+// ExplorationEvent is one state change a Pathfinder.Step produced this
+// tick: a new frontier node spawned and what status it came back with. It
+// mirrors Event's shape so a future /ws stream of a live simulation could
+// reuse the same framing, even though today the only consumer is
+// runPathfindingSimulation running a Pathfinder silently to completion.
+type ExplorationEvent struct {
+	Type          EventType
+	ExplorationID string
+	ParentID      *string
+	Position      Position
+	FScore        *float64
+}
+
+// Pathfinder explores a maze one tick at a time, populating game.Explorations
+// exactly as the caller-driven move API always has - via NewExploration and
+// logMove - so generateMazePNG/generateMazeGIF/the viewer keep working
+// unmodified; they only ever look at Explorations, never at how it got
+// populated. ?algorithm= on the render endpoint picks which one a fresh
+// simulation game runs to completion before being rendered.
+type Pathfinder interface {
+	// Name identifies the algorithm, matching its key in pathfinders.
+	Name() string
+	// Step advances the search by one tick - expanding a single frontier
+	// node - and returns the events it produced. It returns nil once Done
+	// reports true.
+	Step(g *Game) []ExplorationEvent
+	// Done reports whether the search has nothing left to do: the goal was
+	// found, the two trees met (bidirectional), or the frontier is empty.
+	Done(g *Game) bool
+}
+
+// frontierNode is one cell a Pathfinder has queued to expand: where it is,
+// which already-spawned exploration led to it, and (for Dijkstra/A*) the
+// path cost accumulated to reach it.
+type frontierNode struct {
+	pos        Position
+	parentID   *string
+	generation int
+	gScore     int
+	fScore     *float64
+	// path is every position this search branch has passed through to
+	// reach pos, root first - threaded from a node to its children as the
+	// frontier expands. spawnPathfinderNode uses it as the spawned
+	// Exploration's PathPositions, since each node otherwise gets its own
+	// fresh single-cell Exploration with no memory of its ancestors, which
+	// left checkWinCondition's GoalCollectReturn/GoalCollectAll unable to
+	// ever see an item visited earlier in the same branch. Empty for the
+	// root node, which falls back to NewExploration's own [pos] path.
+	path []Position
+}
+
+// extendPath copies base and appends next, so a node's children each get
+// their own path slice - appending to a shared slice directly would let
+// siblings clobber each other's tail through a common backing array.
+func extendPath(base []Position, next Position) []Position {
+	path := make([]Position, len(base), len(base)+1)
+	copy(path, base)
+	return append(path, next)
+}
+
+// spawnPathfinderNode creates a new Exploration for node under id, exactly
+// as moveExploration creates one for a brand-new name - same color
+// assignment and visited-position bookkeeping - then classifies it exactly
+// as moveExploration classifies a move's outcome (goal/dead end/junction/
+// continue), so every Pathfinder produces an Explorations tree the existing
+// renderers already know how to draw. checkGoal is false for bidirectional
+// search's backward half, whose seed sits on the goal cell itself and so
+// would otherwise "win" before it has expanded anything.
+func (g *Game) spawnPathfinderNode(id string, node frontierNode, checkGoal bool) (*Exploration, string) {
+	colorIndex := g.NextExplorationID % 6
+	g.NextExplorationID++
+
+	exp := NewExploration(id, node.pos, node.pos, node.parentID, node.generation, colorIndex)
+	if len(node.path) > 0 {
+		exp.PathPositions = node.path
+	}
+	exp.FScore = node.fScore
+	g.Explorations[id] = exp
+	g.GlobalVisitedPositions.Insert(node.pos)
+
+	status := "continue"
+	switch {
+	case checkGoal && g.checkWinCondition(exp):
+		exp.FoundGoal = true
+		exp.IsActive = false
+		exp.IsComplete = true
+		g.GoalFound = true
+		winner := id
+		g.WinningExploration = &winner
+		status = "goal_reached"
+	default:
+		switch validMoves := g.getValidDirections(node.pos); {
+		case len(validMoves) == 0:
+			exp.IsDead = true
+			exp.IsActive = false
+			exp.IsComplete = true
+			status = "dead_end"
+		case len(validMoves) > 1:
+			status = "junction"
+		}
+	}
+
+	g.logMove(id, node.pos, node.pos, status)
+	return exp, status
+}
+
+// bfsPathfinder grows one tree outward from Start in the order cells are
+// discovered - expanding the oldest frontier entry first, same as the
+// caller-driven move API's branching shape, just driven autonomously
+// instead of by external move requests.
+type bfsPathfinder struct {
+	queue  []frontierNode
+	seeded bool
+}
+
+func (p *bfsPathfinder) Name() string { return "bfs" }
+
+func (p *bfsPathfinder) Done(g *Game) bool {
+	return g.GoalFound || (p.seeded && len(p.queue) == 0)
+}
+
+func (p *bfsPathfinder) Step(g *Game) []ExplorationEvent {
+	if !p.seeded {
+		p.seeded = true
+		p.queue = []frontierNode{{pos: g.Start}}
+	}
+	if p.Done(g) {
+		return nil
+	}
+
+	node := p.queue[0]
+	p.queue = p.queue[1:]
+	if g.isCollision(node.pos) {
+		return nil
+	}
+
+	id := fmt.Sprintf("bfs-%d", g.NextExplorationID)
+	exp, status := g.spawnPathfinderNode(id, node, true)
+	events := []ExplorationEvent{{Type: moveLogEventType(status), ExplorationID: id, ParentID: node.parentID, Position: node.pos}}
+
+	if status != "dead_end" && status != "goal_reached" {
+		for _, dir := range g.getValidDirections(node.pos) {
+			next := node.pos.Add(dir)
+			if g.isCollision(next) {
+				continue
+			}
+			parent := id
+			p.queue = append(p.queue, frontierNode{pos: next, parentID: &parent, generation: exp.Generation + 1, path: extendPath(exp.PathPositions, next)})
+		}
+	}
+
+	return events
+}
+
+// pqItem is one entry in a priorityFrontier: a frontierNode ordered by
+// priority (gScore for Dijkstra, gScore+heuristic for A*), with seq as a
+// FIFO tiebreaker so equal-priority nodes still expand in discovery order.
+type pqItem struct {
+	node     frontierNode
+	priority float64
+	seq      int
+}
+
+// priorityFrontier is a container/heap min-heap of pqItems, shared by
+// dijkstraPathfinder and astarPathfinder - they differ only in what
+// priority they push a neighbor with.
+type priorityFrontier []pqItem
+
+func (pf priorityFrontier) Len() int { return len(pf) }
+func (pf priorityFrontier) Less(i, j int) bool {
+	if pf[i].priority != pf[j].priority {
+		return pf[i].priority < pf[j].priority
+	}
+	return pf[i].seq < pf[j].seq
+}
+func (pf priorityFrontier) Swap(i, j int) { pf[i], pf[j] = pf[j], pf[i] }
+func (pf *priorityFrontier) Push(x interface{}) {
+	*pf = append(*pf, x.(pqItem))
+}
+func (pf *priorityFrontier) Pop() interface{} {
+	old := *pf
+	n := len(old)
+	item := old[n-1]
+	*pf = old[:n-1]
+	return item
+}
+
+// dijkstraPathfinder grows one tree outward from Start, always expanding
+// the frontier node with the smallest accumulated path cost next. Every
+// edge in this grid costs 1, so it explores in the same order BFS does -
+// that's Dijkstra's actual behavior on an unweighted graph, not a
+// shortcut - it's kept as its own implementation (a real priority queue,
+// not a plain FIFO) so its frontier ordering is honest rather than an
+// alias for bfsPathfinder.
+type dijkstraPathfinder struct {
+	frontier priorityFrontier
+	seeded   bool
+	nextSeq  int
+}
+
+func (p *dijkstraPathfinder) Name() string { return "dijkstra" }
+
+func (p *dijkstraPathfinder) Done(g *Game) bool {
+	return g.GoalFound || (p.seeded && p.frontier.Len() == 0)
+}
+
+func (p *dijkstraPathfinder) Step(g *Game) []ExplorationEvent {
+	if !p.seeded {
+		p.seeded = true
+		heap.Init(&p.frontier)
+		heap.Push(&p.frontier, pqItem{node: frontierNode{pos: g.Start}, seq: p.nextSeq})
+		p.nextSeq++
+	}
+	if p.Done(g) {
+		return nil
+	}
+
+	node := heap.Pop(&p.frontier).(pqItem).node
+	if g.isCollision(node.pos) {
+		return nil
+	}
+
+	id := fmt.Sprintf("dijkstra-%d", g.NextExplorationID)
+	exp, status := g.spawnPathfinderNode(id, node, true)
+	events := []ExplorationEvent{{Type: moveLogEventType(status), ExplorationID: id, ParentID: node.parentID, Position: node.pos}}
+
+	if status != "dead_end" && status != "goal_reached" {
+		for _, dir := range g.getValidDirections(node.pos) {
+			next := node.pos.Add(dir)
+			if g.isCollision(next) {
+				continue
+			}
+			parent := id
+			gScore := node.gScore + 1
+			heap.Push(&p.frontier, pqItem{
+				node:     frontierNode{pos: next, parentID: &parent, generation: exp.Generation + 1, gScore: gScore, path: extendPath(exp.PathPositions, next)},
+				priority: float64(gScore),
+				seq:      p.nextSeq,
+			})
+			p.nextSeq++
+		}
+	}
+
+	return events
+}
+
+// manhattan is A*'s admissible heuristic on this grid: no diagonal moves,
+// so the true remaining distance is never less than |dx| + |dy|.
+func manhattan(a, b Position) int {
+	return absInt(a.X-b.X) + absInt(a.Y-b.Y)
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// astarPathfinder is dijkstraPathfinder with Manhattan-distance-to-Goal
+// added to every node's priority, so it explores toward the goal instead
+// of spreading out evenly - each spawned Exploration keeps the f-score it
+// was expanded with (see markerScaleForFScore) so a render can show the
+// heuristic's effect directly.
+type astarPathfinder struct {
+	frontier priorityFrontier
+	seeded   bool
+	nextSeq  int
+}
+
+func (p *astarPathfinder) Name() string { return "astar" }
+
+func (p *astarPathfinder) Done(g *Game) bool {
+	return g.GoalFound || (p.seeded && p.frontier.Len() == 0)
+}
+
+func (p *astarPathfinder) Step(g *Game) []ExplorationEvent {
+	if !p.seeded {
+		p.seeded = true
+		heap.Init(&p.frontier)
+		startF := float64(manhattan(g.Start, g.Goal))
+		heap.Push(&p.frontier, pqItem{node: frontierNode{pos: g.Start}, priority: startF, seq: p.nextSeq})
+		p.nextSeq++
+	}
+	if p.Done(g) {
+		return nil
+	}
+
+	item := heap.Pop(&p.frontier).(pqItem)
+	node := item.node
+	if g.isCollision(node.pos) {
+		return nil
+	}
+	fScore := item.priority
+	node.fScore = &fScore
+
+	id := fmt.Sprintf("astar-%d", g.NextExplorationID)
+	exp, status := g.spawnPathfinderNode(id, node, true)
+	events := []ExplorationEvent{{Type: moveLogEventType(status), ExplorationID: id, ParentID: node.parentID, Position: node.pos, FScore: &fScore}}
+
+	if status != "dead_end" && status != "goal_reached" {
+		for _, dir := range g.getValidDirections(node.pos) {
+			next := node.pos.Add(dir)
+			if g.isCollision(next) {
+				continue
+			}
+			parent := id
+			gScore := node.gScore + 1
+			f := float64(gScore) + float64(manhattan(next, g.Goal))
+			heap.Push(&p.frontier, pqItem{
+				node:     frontierNode{pos: next, parentID: &parent, generation: exp.Generation + 1, gScore: gScore, path: extendPath(exp.PathPositions, next)},
+				priority: f,
+				seq:      p.nextSeq,
+			})
+			p.nextSeq++
+		}
+	}
+
+	return events
+}
+
+// bidirectionalPathfinder grows two trees at once - forward from Start,
+// backward from Goal - alternating which one expands next, until a node
+// either side is about to claim is already owned by the other: that's the
+// two trees meeting in the middle, so it reports the meeting exploration
+// as the winner instead of ever expecting either tree to reach the other's
+// endpoint on its own.
+type bidirectionalPathfinder struct {
+	forward, backward           []frontierNode
+	forwardOwner, backwardOwner map[Position]string
+	seeded                      bool
+	turnForward                 bool
+	met                         bool
+}
+
+func (p *bidirectionalPathfinder) Name() string { return "bidirectional" }
+
+func (p *bidirectionalPathfinder) Done(g *Game) bool {
+	return g.GoalFound || p.met || (p.seeded && len(p.forward) == 0 && len(p.backward) == 0)
+}
+
+func (p *bidirectionalPathfinder) Step(g *Game) []ExplorationEvent {
+	if !p.seeded {
+		p.seeded = true
+		p.forward = []frontierNode{{pos: g.Start}}
+		p.backward = []frontierNode{{pos: g.Goal}}
+		p.forwardOwner = make(map[Position]string)
+		p.backwardOwner = make(map[Position]string)
+	}
+	if p.Done(g) {
+		return nil
+	}
+
+	expandForward := p.turnForward
+	if expandForward && len(p.forward) == 0 {
+		expandForward = false
+	}
+	if !expandForward && len(p.backward) == 0 {
+		expandForward = true
+	}
+	p.turnForward = !p.turnForward
+
+	if expandForward {
+		return p.expand(g, "fwd", &p.forward, p.forwardOwner, p.backwardOwner, true)
+	}
+	return p.expand(g, "bwd", &p.backward, p.backwardOwner, p.forwardOwner, false)
+}
+
+// expand pops the next queued node for one side and either spawns it as a
+// new Exploration and fans its unclaimed neighbors back into the queue, or
+// - if the other side already owns this cell - declares a meeting and
+// marks both sides' explorations at the contact point as the winner.
+func (p *bidirectionalPathfinder) expand(g *Game, side string, queue *[]frontierNode, ownOwner, otherOwner map[Position]string, checkGoal bool) []ExplorationEvent {
+	node := (*queue)[0]
+	*queue = (*queue)[1:]
+
+	if _, already := ownOwner[node.pos]; already {
+		return nil
+	}
+
+	if otherID, met := otherOwner[node.pos]; met {
+		p.met = true
+		g.GoalFound = true
+		g.WinningExploration = &otherID
+		if otherExp, ok := g.Explorations[otherID]; ok {
+			otherExp.FoundGoal = true
+			otherExp.IsActive = false
+			otherExp.IsComplete = true
+		}
+		if node.parentID != nil {
+			if parentExp, ok := g.Explorations[*node.parentID]; ok {
+				parentExp.FoundGoal = true
+				parentExp.IsActive = false
+				parentExp.IsComplete = true
+			}
+		}
+		return []ExplorationEvent{{Type: EventGoalReached, ExplorationID: otherID, Position: node.pos}}
+	}
+
+	id := fmt.Sprintf("bidi-%s-%d", side, g.NextExplorationID)
+	exp, status := g.spawnPathfinderNode(id, node, checkGoal)
+	ownOwner[node.pos] = id
+	events := []ExplorationEvent{{Type: moveLogEventType(status), ExplorationID: id, ParentID: node.parentID, Position: node.pos}}
+
+	if status == "dead_end" || status == "goal_reached" {
+		return events
+	}
+
+	for _, dir := range g.getValidDirections(node.pos) {
+		next := node.pos.Add(dir)
+		if _, already := ownOwner[next]; already {
+			continue
+		}
+		parent := id
+		*queue = append(*queue, frontierNode{pos: next, parentID: &parent, generation: exp.Generation + 1, path: extendPath(exp.PathPositions, next)})
+	}
+
+	return events
+}
+
+// pathfinders maps each ?algorithm= name to a constructor for a fresh
+// Pathfinder instance - a constructor, not a shared value, because every
+// implementation above carries its own frontier/visited state across Step
+// calls.
+var pathfinders = map[string]func() Pathfinder{
+	"bfs":           func() Pathfinder { return &bfsPathfinder{} },
+	"astar":         func() Pathfinder { return &astarPathfinder{} },
+	"dijkstra":      func() Pathfinder { return &dijkstraPathfinder{} },
+	"bidirectional": func() Pathfinder { return &bidirectionalPathfinder{} },
+}
+
+const defaultPathfindingAlgorithm = "bfs"
+
+// resolvePathfinder looks up algo in pathfinders, falling back to
+// defaultPathfindingAlgorithm for "" or an unrecognized name, mirroring
+// resolveMazeGenerator. It returns a fresh Pathfinder along with the name
+// actually used.
+func resolvePathfinder(algo string) (Pathfinder, string) {
+	if newPathfinder, ok := pathfinders[algo]; ok {
+		return newPathfinder(), algo
+	}
+	return pathfinders[defaultPathfindingAlgorithm](), defaultPathfindingAlgorithm
+}
+
+// maxPathfindingSteps bounds a simulation in case a Pathfinder
+// implementation never reports Done. It's a fixed step count, not scaled
+// to Width*Height: a small maze finishes well inside it, but a search over
+// a maze with more cells than that can stop before it's Done.
+const maxPathfindingSteps = 100000
+
+// runPathfindingSimulation builds a scratch Game sharing origin's maze,
+// start/goal, and goal mode, runs algo's Pathfinder over it to completion,
+// and returns the scratch game so its resulting Explorations - and nothing
+// about the live, caller-driven origin - can be handed to generateMazePNG,
+// which only ever looks at Explorations.
+func runPathfindingSimulation(origin *Game, algo string) (*Game, string) {
+	pathfinder, resolved := resolvePathfinder(algo)
+
+	sim := &Game{
+		Maze:                   origin.Maze,
+		Width:                  origin.Width,
+		Height:                 origin.Height,
+		Start:                  origin.Start,
+		Goal:                   origin.Goal,
+		GoalMode:               origin.GoalMode,
+		Goals:                  origin.Goals,
+		Items:                  origin.Items,
+		Algorithm:              origin.Algorithm,
+		Explorations:           make(map[string]*Exploration),
+		GlobalVisitedPositions: newKDTree(),
+		Pathfinder:             pathfinder,
+	}
+
+	for i := 0; i < maxPathfindingSteps && !sim.Pathfinder.Done(sim); i++ {
+		sim.Pathfinder.Step(sim)
+	}
+
+	return sim, resolved
+}
+
+// newWalledGrid returns a width*height grid that's all WALL except for
+// PATH at every odd (x, y) - the fixed cell centers every generator below
+// carves passages between.
+func newWalledGrid(width, height int) [][]CellType {
+	maze := make([][]CellType, height)
+	for y := 0; y < height; y++ {
+		maze[y] = make([]CellType, width)
+	}
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			maze[y][x] = PATH
+		}
+	}
+	return maze
+}
+
+// carve opens the wall cell between two orthogonally-adjacent cell
+// centers (both odd coordinates, 2 apart).
+func carve(maze [][]CellType, a, b Position) {
+	maze[(a.Y+b.Y)/2][(a.X+b.X)/2] = PATH
+}
+
+// finalizeMaze fixes Start at the grid's first cell and Goal at the
+// carved cell farthest from it by Manhattan distance - the same rule the
+// original recursive-backtracker used - then stamps both onto maze so
+// every algorithm picks comparable Start/Goal pairs.
+func finalizeMaze(maze [][]CellType, width, height int) (start, goal Position) {
+	start = Position{1, 1}
+
+	maxDist := 0
+	goal = Position{width - 2, height - 2}
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			if maze[y][x] == PATH {
+				dist := abs(x-1) + abs(y-1)
+				if dist > maxDist {
+					maxDist = dist
+					goal = Position{x, y}
+				}
+			}
+		}
+	}
+
+	maze[start.Y][start.X] = START
+	maze[goal.Y][goal.X] = GOAL
+	return start, goal
+}
+
+// cellDirections are the four moves between adjacent cell centers - two
+// grid steps, so the wall between them sits at the midpoint carve() opens.
+var cellDirections = []Direction{{0, -2}, {2, 0}, {0, 2}, {-2, 0}}
+
+// recursiveBacktrackerGenerator is the original hardcoded algorithm: a
+// randomized depth-first carve, followed by knocking down a handful of
+// extra walls so the result isn't a "perfect" single-solution maze.
+type recursiveBacktrackerGenerator struct{}
+
+func (recursiveBacktrackerGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	stack := []Position{{1, 1}}
+	visited := map[Position]bool{{1, 1}: true}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		var neighbors []Position
+		for _, dir := range cellDirections {
+			next := Position{current.X + dir.X, current.Y + dir.Y}
+			if next.X >= 1 && next.X < width-1 &&
+				next.Y >= 1 && next.Y < height-1 &&
+				!visited[next] {
+				neighbors = append(neighbors, next)
+			}
+		}
+
+		if len(neighbors) > 0 {
+			next := neighbors[rng.Intn(len(neighbors))]
+			visited[next] = true
+			carve(maze, current, next)
+			stack = append(stack, next)
+		} else {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for i := 0; i < width*height/30; i++ {
+		x := 2 + rng.Intn((width-4)/2)*2
+		y := 2 + rng.Intn((height-4)/2)*2
+
+		for _, dir := range []Direction{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+			nx, ny := x+dir.X, y+dir.Y
+			if nx >= 0 && nx < width && ny >= 0 && ny < height &&
+				maze[ny][nx] == PATH {
+				maze[y][x] = PATH
+				break
+			}
+		}
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+// primGenerator is randomized Prim's algorithm: grow the maze from a
+// single cell by repeatedly carving into a random frontier cell.
+type primGenerator struct{}
+
+func (primGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	type frontierEdge struct{ from, to Position }
+
+	inMaze := map[Position]bool{{1, 1}: true}
+	var frontier []frontierEdge
+
+	addFrontier := func(cell Position) {
+		for _, dir := range cellDirections {
+			next := Position{cell.X + dir.X, cell.Y + dir.Y}
+			if next.X >= 1 && next.X < width-1 && next.Y >= 1 && next.Y < height-1 && !inMaze[next] {
+				frontier = append(frontier, frontierEdge{cell, next})
+			}
+		}
+	}
+	addFrontier(Position{1, 1})
+
+	for len(frontier) > 0 {
+		i := rng.Intn(len(frontier))
+		edge := frontier[i]
+		frontier = append(frontier[:i], frontier[i+1:]...)
+
+		if inMaze[edge.to] {
+			continue
+		}
+		carve(maze, edge.from, edge.to)
+		inMaze[edge.to] = true
+		addFrontier(edge.to)
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+// kruskalGenerator is randomized Kruskal's algorithm: shuffle every
+// candidate wall between adjacent cells and knock it down whenever the
+// two cells aren't already connected, tracked with union-find.
+type kruskalGenerator struct{}
+
+func (kruskalGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	var cells []Position
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			cells = append(cells, Position{x, y})
+		}
+	}
+
+	type edge struct{ a, b Position }
+	var edges []edge
+	for _, c := range cells {
+		if right := (Position{c.X + 2, c.Y}); right.X < width-1 {
+			edges = append(edges, edge{c, right})
+		}
+		if down := (Position{c.X, c.Y + 2}); down.Y < height-1 {
+			edges = append(edges, edge{c, down})
+		}
+	}
+	rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	parent := make(map[Position]Position, len(cells))
+	for _, c := range cells {
+		parent[c] = c
+	}
+	var find func(Position) Position
+	find = func(p Position) Position {
+		if parent[p] != p {
+			parent[p] = find(parent[p])
+		}
+		return parent[p]
+	}
+
+	for _, e := range edges {
+		ra, rb := find(e.a), find(e.b)
+		if ra != rb {
+			parent[ra] = rb
+			carve(maze, e.a, e.b)
+		}
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+// wilsonGenerator builds a uniform spanning tree via loop-erased random
+// walks: each still-unvisited cell walks randomly, overwriting its own
+// outgoing step whenever it revisits a cell (which erases the loop it
+// just made), until the walk reaches the maze-so-far. Unlike the other
+// algorithms here, every spanning tree is equally likely, which makes it
+// a useful baseline for comparing exploration strategies against mazes
+// that aren't biased toward long corridors or short dead ends.
+type wilsonGenerator struct{}
+
+func (wilsonGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	var cells []Position
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			cells = append(cells, Position{x, y})
+		}
+	}
+
+	neighborsOf := func(p Position) []Position {
+		var out []Position
+		for _, dir := range cellDirections {
+			next := Position{p.X + dir.X, p.Y + dir.Y}
+			if next.X >= 1 && next.X < width-1 && next.Y >= 1 && next.Y < height-1 {
+				out = append(out, next)
+			}
+		}
+		return out
+	}
+
+	inMaze := map[Position]bool{cells[0]: true}
+
+	for _, start := range cells {
+		if inMaze[start] {
+			continue
+		}
+
+		next := map[Position]Position{}
+		current := start
+		for !inMaze[current] {
+			options := neighborsOf(current)
+			step := options[rng.Intn(len(options))]
+			next[current] = step
+			current = step
+		}
+
+		for cell := start; !inMaze[cell]; cell = next[cell] {
+			carve(maze, cell, next[cell])
+			inMaze[cell] = true
+		}
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+// binaryTreeGenerator is the binary tree algorithm: every cell carves
+// toward north or west, whichever is available, chosen at random. It's the
+// cheapest of the bunch but biases corridors toward the top-left corner.
+type binaryTreeGenerator struct{}
+
+func (binaryTreeGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			var options []Direction
+			if y > 1 {
+				options = append(options, Direction{0, -2})
+			}
+			if x > 1 {
+				options = append(options, Direction{-2, 0})
+			}
+			if len(options) == 0 {
+				continue
+			}
+			dir := options[rng.Intn(len(options))]
+			carve(maze, Position{x, y}, Position{x + dir.X, y + dir.Y})
+		}
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+// ellerGenerator is Eller's algorithm: it carves one row of cells at a
+// time, randomly merging adjacent cells within a row and then randomly
+// dropping at least one passage per row-set down into the next row, so
+// the whole maze is produced without ever holding more than one row of
+// state. The last row merges every remaining adjacent pair so no set is
+// left stranded.
+type ellerGenerator struct{}
+
+func (ellerGenerator) Generate(width, height int, rng *rand.Rand) ([][]CellType, Position, Position) {
+	maze := newWalledGrid(width, height)
+
+	cols := (width - 1) / 2
+	rows := (height - 1) / 2
+
+	cellAt := func(col, row int) Position {
+		return Position{col*2 + 1, row*2 + 1}
+	}
+
+	set := make([]int, cols)
+	nextSet := 0
+	for i := range set {
+		set[i] = nextSet
+		nextSet++
+	}
+
+	for row := 0; row < rows; row++ {
+		isLastRow := row == rows-1
+
+		for col := 0; col < cols-1; col++ {
+			if set[col] == set[col+1] {
+				continue
+			}
+			if isLastRow || rng.Intn(2) == 0 {
+				carve(maze, cellAt(col, row), cellAt(col+1, row))
+				merged := set[col+1]
+				for i, s := range set {
+					if s == merged {
+						set[i] = set[col]
+					}
+				}
+			}
+		}
+
+		if isLastRow {
+			break
+		}
+
+		bySet := map[int][]int{}
+		for col, s := range set {
+			bySet[s] = append(bySet[s], col)
+		}
+		setIDs := make([]int, 0, len(bySet))
+		for s := range bySet {
+			setIDs = append(setIDs, s)
+		}
+		sort.Ints(setIDs)
+
+		nextRowSet := make([]int, cols)
+		for i := range nextRowSet {
+			nextRowSet[i] = -1
+		}
+
+		for _, s := range setIDs {
+			setCols := bySet[s]
+			rng.Shuffle(len(setCols), func(i, j int) { setCols[i], setCols[j] = setCols[j], setCols[i] })
+			drops := 1 + rng.Intn(len(setCols))
+			for _, col := range setCols[:drops] {
+				carve(maze, cellAt(col, row), cellAt(col, row+1))
+				nextRowSet[col] = s
+			}
+		}
+
+		for col := range nextRowSet {
+			if nextRowSet[col] == -1 {
+				nextRowSet[col] = nextSet
+				nextSet++
+			}
+		}
+		set = nextRowSet
+	}
+
+	start, goal := finalizeMaze(maze, width, height)
+	return maze, start, goal
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// kdNode is one node of a 2D KD-tree over Position, splitting on X at
+// even depth and Y at odd depth.
+type kdNode struct {
+	pos         Position
+	left, right *kdNode
+}
+
+// kdTree indexes GlobalVisitedPositions so handlers get O(log n)
+// membership checks plus the spatial queries a map can't answer without a
+// full scan - nearest-unvisited and visited-within-radius - which is what
+// keeps /move and /nearest-unvisited off a linear scan once mazes get
+// into the 1000x1000+ range.
+type kdTree struct {
+	root  *kdNode
+	count int
+}
+
+func newKDTree() *kdTree {
+	return &kdTree{}
+}
+
+func (t *kdTree) Len() int {
+	return t.count
+}
+
+// kdLess reports whether a sorts before b on depth's splitting axis: X at
+// even depth, Y at odd depth.
+func kdLess(a, b Position, depth int) bool {
+	if depth%2 == 0 {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+
+func (t *kdTree) Contains(pos Position) bool {
+	node := t.root
+	depth := 0
+	for node != nil {
+		if node.pos == pos {
+			return true
+		}
+		if kdLess(pos, node.pos, depth) {
+			node = node.left
+		} else {
+			node = node.right
+		}
+		depth++
+	}
+	return false
+}
+
+// Insert is a no-op if pos is already indexed, so re-visiting a cell
+// doesn't grow the tree.
+func (t *kdTree) Insert(pos Position) {
+	if t.Contains(pos) {
+		return
+	}
+	t.root = insertKD(t.root, pos, 0)
+	t.count++
+}
+
+func insertKD(node *kdNode, pos Position, depth int) *kdNode {
+	if node == nil {
+		return &kdNode{pos: pos}
+	}
+	if kdLess(pos, node.pos, depth) {
+		node.left = insertKD(node.left, pos, depth+1)
+	} else {
+		node.right = insertKD(node.right, pos, depth+1)
+	}
+	return node
+}
+
+// All returns every indexed position, for callers (snapshot export, JSON
+// persistence) that need the full visited set rather than a query.
+func (t *kdTree) All() []Position {
+	out := make([]Position, 0, t.count)
+	var walk func(*kdNode)
+	walk = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		out = append(out, n.pos)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+func sqDist(a, b Position) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// VisitedInRadius returns every indexed position within r of center
+// (inclusive, squared-distance comparison), pruning subtrees whose
+// splitting plane is already farther than r away.
+func (t *kdTree) VisitedInRadius(center Position, r int) []Position {
+	var out []Position
+	rSq := r * r
+
+	var search func(node *kdNode, depth int)
+	search = func(node *kdNode, depth int) {
+		if node == nil {
+			return
+		}
+		if sqDist(center, node.pos) <= rSq {
+			out = append(out, node.pos)
+		}
+
+		var axisDist int
+		if depth%2 == 0 {
+			axisDist = center.X - node.pos.X
+		} else {
+			axisDist = center.Y - node.pos.Y
+		}
+
+		near, far := node.left, node.right
+		if axisDist > 0 {
+			near, far = node.right, node.left
+		}
+		search(near, depth+1)
+		if axisDist*axisDist <= rSq {
+			search(far, depth+1)
+		}
+	}
+	search(t.root, 0)
+	return out
+}
+
+// Remove deletes pos if present. Proper kd-tree deletion needs a
+// replacement-node search across subtrees for every removal; undo only
+// needs to drop one position at a time, so it's simpler - and no slower in
+// practice - to rebuild from the remaining positions instead.
+func (t *kdTree) Remove(pos Position) {
+	if !t.Contains(pos) {
+		return
+	}
+	fresh := newKDTree()
+	for _, p := range t.All() {
+		if p != pos {
+			fresh.Insert(p)
+		}
+	}
+	*t = *fresh
+}
+
+func (g *Game) isWalkable(pos Position) bool {
+	if pos.X < 0 || pos.X >= g.Width || pos.Y < 0 || pos.Y >= g.Height {
+		return false
+	}
+	return g.Maze[pos.Y][pos.X] != WALL
+}
+
+func (g *Game) isCollision(pos Position) bool {
+	return g.GlobalVisitedPositions.Contains(pos)
+}
+
+// ringPositions returns the cells exactly r Chebyshev-squares from center
+// - the boundary of an expanding square search outward from center.
+func ringPositions(center Position, r int) []Position {
+	if r == 0 {
+		return []Position{center}
+	}
+	out := make([]Position, 0, 8*r)
+	for dx := -r; dx <= r; dx++ {
+		out = append(out, Position{center.X + dx, center.Y - r})
+		out = append(out, Position{center.X + dx, center.Y + r})
+	}
+	for dy := -r + 1; dy <= r-1; dy++ {
+		out = append(out, Position{center.X - r, center.Y + dy})
+		out = append(out, Position{center.X + r, center.Y + dy})
+	}
+	return out
+}
+
+// nearestUnvisited finds the walkable, not-yet-visited cell closest to
+// from, using the standard KD-tree bounding-distance prune: rings expand
+// outward from from, but a ring only rules out a closer match once the
+// ring itself is farther away than the best candidate found so far - a
+// ring r's nearest point is r cells away, so once r*r exceeds bestDist no
+// later ring can improve on it. GlobalVisitedPositions' KD-tree gives each
+// candidate an O(log n) visited check instead of the O(n) scan a naive
+// "closest unvisited" search would otherwise need.
+func (g *Game) nearestUnvisited(from Position) (Position, bool) {
+	maxRadius := g.Width
+	if g.Height > maxRadius {
+		maxRadius = g.Height
+	}
+
+	var best Position
+	bestDist := 0
+	found := false
+
+	for r := 0; r <= maxRadius; r++ {
+		if found && r*r > bestDist {
+			break
+		}
+
+		for _, cand := range ringPositions(from, r) {
+			if !g.isWalkable(cand) || g.GlobalVisitedPositions.Contains(cand) {
+				continue
+			}
+			d := sqDist(from, cand)
+			if !found || d < bestDist {
+				found, bestDist, best = true, d, cand
+			}
+		}
+	}
+	return best, found
+}
+
+func (g *Game) getValidDirections(pos Position) []Direction {
+	var valid []Direction
+	directions := []Direction{UP, DOWN, LEFT, RIGHT}
+
+	for _, dir := range directions {
+		newPos := pos.Add(dir)
+		if g.isWalkable(newPos) && !g.isCollision(newPos) {
+			valid = append(valid, dir)
+		}
+	}
+	return valid
 }
 
+// placeGoalModeCells resolves mode and, for modes beyond GoalSingle,
+// scatters the extra GOAL/ITEM cells that mode needs across the maze's
+// open path cells.
+func (g *Game) placeGoalModeCells(rng *rand.Rand, mode GoalMode) {
+	g.GoalMode = resolveGoalMode(mode)
+	g.Goals = []Position{g.Goal}
+
+	switch g.GoalMode {
+	case GoalMulti:
+		extra := pickRandomPathCells(g.Maze, g.Width, g.Height, rng, 2)
+		g.Goals = append(g.Goals, extra...)
+		for _, p := range extra {
+			g.Maze[p.Y][p.X] = GOAL
+		}
+	case GoalCollectReturn:
+		g.Items = pickRandomPathCells(g.Maze, g.Width, g.Height, rng, 1)
+		for _, p := range g.Items {
+			g.Maze[p.Y][p.X] = ITEM
+		}
+	case GoalCollectAll:
+		g.Items = pickRandomPathCells(g.Maze, g.Width, g.Height, rng, 3)
+		for _, p := range g.Items {
+			g.Maze[p.Y][p.X] = ITEM
+		}
+	}
+}
+
+// pickRandomPathCells returns up to n distinct PATH cells chosen at
+// random, for scattering extra goals/items without disturbing Start,
+// Goal, or the maze's wall structure.
+func pickRandomPathCells(maze [][]CellType, width, height int, rng *rand.Rand, n int) []Position {
+	var candidates []Position
+	for y := 1; y < height-1; y += 2 {
+		for x := 1; x < width-1; x += 2 {
+			if maze[y][x] == PATH {
+				candidates = append(candidates, Position{x, y})
+			}
+		}
+	}
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	return candidates[:n]
+}
+
+// isAtGoal reports whether pos is a winning cell - the single Goal
+// normally, or any cell in Goals under GoalMulti.
 func (g *Game) isAtGoal(pos Position) bool {
-	return pos.X == g.Goal.X && pos.Y == g.Goal.Y
+	for _, goal := range g.Goals {
+		if pos == goal {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWinCondition reports whether exploration satisfies the game's
+// GoalMode, based on everywhere it has been (PathPositions) and where it
+// is now (CurrentPosition).
+func (g *Game) checkWinCondition(exploration *Exploration) bool {
+	switch g.GoalMode {
+	case GoalCollectReturn:
+		return exploration.CurrentPosition == g.Start && pathVisitsAny(exploration.PathPositions, g.Items)
+	case GoalCollectAll:
+		return g.isAtGoal(exploration.CurrentPosition) && pathVisitsAll(exploration.PathPositions, g.Items)
+	default:
+		return g.isAtGoal(exploration.CurrentPosition)
+	}
+}
+
+func pathContains(path []Position, target Position) bool {
+	for _, p := range path {
+		if p == target {
+			return true
+		}
+	}
+	return false
+}
+
+func pathVisitsAny(path, targets []Position) bool {
+	for _, t := range targets {
+		if pathContains(path, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathVisitsAll(path, targets []Position) bool {
+	for _, t := range targets {
+		if !pathContains(path, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// itemsRemaining counts the items in g.Items that visited (an exploration's
+// PathPositions) doesn't already contain - nil visited means no exploration
+// is in scope, so every item still counts as remaining.
+func (g *Game) itemsRemaining(visited []Position) int {
+	remaining := 0
+	for _, item := range g.Items {
+		if !pathContains(visited, item) {
+			remaining++
+		}
+	}
+	return remaining
 }
 
-func (g *Game) getMazeStatus(pos Position) MazeStatusResponse {
+func (g *Game) getMazeStatus(pos Position, visited []Position) MazeStatusResponse {
 	validDirections := g.getValidDirections(pos)
 	
 	// Create AvailableMoves with target positions
@@ -607,12 +2825,14 @@ func (g *Game) getMazeStatus(pos Position) MazeStatusResponse {
 	}
 	
 	return MazeStatusResponse{
-		IsExplored:          g.GlobalVisitedPositions[pos],
+		IsExplored:          g.GlobalVisitedPositions.Contains(pos),
 		IsJunction:          len(validDirections) > 1,
 		AvailableDirections: validDirections,
 		AvailableMoves:      availableMoves,
 		IsGoal:              g.isAtGoal(pos),
 		GoalReachedByAny:    g.GoalFound,
+		ItemsRemaining:      g.itemsRemaining(visited),
+		MustReturnToStart:   g.GoalMode == GoalCollectReturn,
 	}
 }
 
@@ -639,26 +2859,28 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 		// Assign color index based on creation order (matching Python version logic)
 		colorIndex := g.NextExplorationID % 6  // 6 colors excluding gold/gray
 		g.NextExplorationID++  // Increment after assigning color
-		
+
 		exploration = NewExploration(explorationName, nextPos, nextPos, nil, 0, colorIndex)
 		g.Explorations[explorationName] = exploration
-		g.GlobalVisitedPositions[nextPos] = true
-		
+		g.GlobalVisitedPositions.Insert(nextPos)
+
 		// For new exploration, don't duplicate the position
-		if g.isAtGoal(nextPos) {
+		if g.checkWinCondition(exploration) {
 			exploration.FoundGoal = true
 			exploration.IsActive = false
 			exploration.IsComplete = true
 			g.GoalFound = true
 			winnerName := explorationName
 			g.WinningExploration = &winnerName
+			g.logMove(explorationName, nextPos, nextPos, "goal_reached")
 			return MoveResponse{
 				Success: true,
 				Message: fmt.Sprintf("Goal reached by %s!", explorationName),
 				NewStatus: "goal_reached",
 			}
 		}
-		
+
+		g.logMove(explorationName, nextPos, nextPos, "continue")
 		return MoveResponse{
 			Success: true,
 			Message: fmt.Sprintf("Exploration '%s' started at (%d, %d)", explorationName, nextPos.X, nextPos.Y),
@@ -669,7 +2891,8 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 	// Check if this is the very first move to (1,1)
 	if explorationName == "root" && nextPos.X == 1 && nextPos.Y == 1 && len(exploration.PathPositions) == 1 {
 		// Root exploration starting - already at start position
-		g.GlobalVisitedPositions[nextPos] = true
+		g.GlobalVisitedPositions.Insert(nextPos)
+		g.logMove(explorationName, nextPos, nextPos, "continue")
 		return MoveResponse{
 			Success: true,
 			Message: "Root exploration started at start position",
@@ -677,17 +2900,19 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 		}
 	}
 
+	from := exploration.CurrentPosition
 	exploration.CurrentPosition = nextPos
 	exploration.PathPositions = append(exploration.PathPositions, nextPos)
-	g.GlobalVisitedPositions[nextPos] = true
+	g.GlobalVisitedPositions.Insert(nextPos)
 
-	if g.isAtGoal(nextPos) {
+	if g.checkWinCondition(exploration) {
 		exploration.FoundGoal = true
 		exploration.IsActive = false
 		exploration.IsComplete = true
 		g.GoalFound = true
 		winnerName := explorationName
 		g.WinningExploration = &winnerName
+		g.logMove(explorationName, from, nextPos, "goal_reached")
 		return MoveResponse{
 			Success: true,
 			Message: fmt.Sprintf("Goal reached by %s!", explorationName),
@@ -700,6 +2925,7 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 		exploration.IsDead = true
 		exploration.IsActive = false
 		exploration.IsComplete = true
+		g.logMove(explorationName, from, nextPos, "dead_end")
 		return MoveResponse{
 			Success: true,
 			Message: "Dead end reached",
@@ -708,6 +2934,7 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 	}
 
 	if len(validMoves) > 1 {
+		g.logMove(explorationName, from, nextPos, "junction")
 		return MoveResponse{
 			Success: true,
 			Message: "Junction reached - can branch explorations",
@@ -715,6 +2942,7 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 		}
 	}
 
+	g.logMove(explorationName, from, nextPos, "continue")
 	return MoveResponse{
 		Success: true,
 		Message: "Moved successfully",
@@ -722,6 +2950,69 @@ func (g *Game) moveExploration(explorationName string, nextPos Position) MoveRes
 	}
 }
 
+// logMove appends an accepted move to MoveLog, so GET /game/{id}/move/{n},
+// POST /replay, and POST /undo all have an ordered record to work from.
+func (g *Game) logMove(explorationName string, from, to Position, status string) {
+	g.MoveLog = append(g.MoveLog, MoveLogEntry{
+		ExplorationName: explorationName,
+		From:            from,
+		To:              to,
+		Timestamp:       time.Now(),
+		NewStatus:       status,
+	})
+}
+
+// popLastMove pops the most recent MoveLog entry and rolls back the
+// exploration it belongs to: CurrentPosition/PathPositions revert to the
+// position before the move, and IsDead/IsComplete/FoundGoal (and GoalFound/
+// WinningExploration, if this move was the win) are re-evaluated as if the
+// move had never happened. It reports false if there's nothing to undo.
+func (g *Game) popLastMove() bool {
+	n := len(g.MoveLog)
+	if n == 0 {
+		return false
+	}
+	entry := g.MoveLog[n-1]
+	g.MoveLog = g.MoveLog[:n-1]
+
+	if g.WinningExploration != nil && *g.WinningExploration == entry.ExplorationName {
+		g.WinningExploration = nil
+		g.GoalFound = false
+	}
+
+	exploration, exists := g.Explorations[entry.ExplorationName]
+	if exists {
+		if len(exploration.PathPositions) <= 1 {
+			delete(g.Explorations, entry.ExplorationName)
+		} else {
+			exploration.PathPositions = exploration.PathPositions[:len(exploration.PathPositions)-1]
+			exploration.CurrentPosition = exploration.PathPositions[len(exploration.PathPositions)-1]
+			exploration.IsDead = false
+			exploration.IsComplete = false
+			exploration.IsActive = true
+			exploration.FoundGoal = false
+		}
+	}
+
+	if !g.positionStillVisited(entry.To) {
+		g.GlobalVisitedPositions.Remove(entry.To)
+	}
+
+	return true
+}
+
+// positionStillVisited reports whether any remaining exploration's path
+// still passes through pos, so popLastMove only drops it from
+// GlobalVisitedPositions once nothing else references it.
+func (g *Game) positionStillVisited(pos Position) bool {
+	for _, exp := range g.Explorations {
+		if pathContains(exp.PathPositions, pos) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Game) getExplorationTree() ExplorationTreeResponse {
 	activeCount := 0
 	for _, exp := range g.Explorations {
@@ -741,20 +3032,65 @@ func (g *Game) getExplorationTree() ExplorationTreeResponse {
 			TotalExplorations:  len(g.Explorations),
 			ActiveExplorations: activeCount,
 			GoalFound:          g.GoalFound,
-			VisitedPositions:   len(g.GlobalVisitedPositions),
+			VisitedPositions:   g.GlobalVisitedPositions.Len(),
+		},
+	}
+}
+
+// toPathSegmentTree builds the same self-contained bundle NewGameFromJSON
+// loads, for use by the snapshot endpoint and any future save-to-disk path.
+func (g *Game) toPathSegmentTree() PathSegmentTree {
+	maze := make([][]int, g.Height)
+	for y := 0; y < g.Height; y++ {
+		maze[y] = make([]int, g.Width)
+		for x := 0; x < g.Width; x++ {
+			maze[y][x] = int(g.Maze[y][x])
+		}
+	}
+
+	visited := g.GlobalVisitedPositions.All()
+
+	return PathSegmentTree{
+		Metadata: Metadata{
+			Width:                 g.Width,
+			Height:                g.Height,
+			Start:                 g.Start,
+			Goal:                  g.Goal,
+			GoalFound:             g.GoalFound,
+			WinningSegment:        g.WinningExploration,
+			ShowOnlyWinner:        g.ShowOnlyWinner,
+			TotalSteps:            g.TotalSteps,
+			MaxConcurrentSegments: g.MaxConcurrentExplorations,
+			NextID:                g.NextExplorationID,
+			Algorithm:             g.Algorithm,
+			GoalMode:              g.GoalMode,
+			Goals:                 g.Goals,
+			Items:                 g.Items,
 		},
+		Maze:                   maze,
+		Segments:               g.Explorations,
+		GlobalVisitedPositions: visited,
+		MoveLog:                g.MoveLog,
 	}
 }
 
 
-func handleRender(w http.ResponseWriter, r *http.Request) {
+func handleRender(w http.ResponseWriter, r *http.Request, game *Game) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Generate PNG content
-	pngData, err := generateMazePNG()
+	// Generate PNG content. ?algorithm= renders a fresh Pathfinder
+	// simulation over this game's maze instead of the live, caller-driven
+	// Explorations - see runPathfindingSimulation.
+	renderGame := game
+	game.mu.RLock()
+	if algo := r.URL.Query().Get("algorithm"); algo != "" {
+		renderGame, _ = runPathfindingSimulation(game, algo)
+	}
+	pngData, err := generateMazePNG(renderGame)
+	game.mu.RUnlock()
 	if err != nil {
 		http.Error(w, "Failed to generate maze image", http.StatusInternalServerError)
 		return
@@ -766,7 +3102,33 @@ func handleRender(w http.ResponseWriter, r *http.Request) {
 	w.Write(pngData)
 }
 
-func generateMazePNG() ([]byte, error) {
+// handleRenderGIF answers POST /game/{id}/render-gif with an animated GIF of
+// the full exploration run so far: one frame every few BFS ticks showing
+// branches mid-growth, plus a final frame of the true end state held for 2
+// seconds. ?frame_interval_ms= sets each frame's display duration (default
+// 200ms).
+func handleRenderGIF(w http.ResponseWriter, r *http.Request, game *Game) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	frameIntervalMs, _ := strconv.Atoi(r.URL.Query().Get("frame_interval_ms"))
+
+	game.mu.RLock()
+	gifData, err := generateMazeGIF(game, frameIntervalMs)
+	game.mu.RUnlock()
+	if err != nil {
+		http.Error(w, "Failed to generate maze animation", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/gif")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"maze.gif\"")
+	w.Write(gifData)
+}
+
+func generateMazePNG(game *Game) ([]byte, error) {
 	cellSize := 20
 	mazeWidth := game.Width * cellSize
 	mazeHeight := game.Height * cellSize
@@ -803,7 +3165,7 @@ func generateMazePNG() ([]byte, error) {
 	}
 	
 	// Draw title content
-	drawTitle(img, totalWidth, titleHeight)
+	drawTitle(img, game, totalWidth, titleHeight)
 
 	// Draw maze background first (matching Python version)
 	for y := titleHeight; y < totalHeight; y++ {
@@ -813,161 +3175,405 @@ func generateMazePNG() ([]byte, error) {
 	}
 	
 	// Draw maze structure (offset by title height)
+	drawMazeWalls(img, game, cellSize, titleHeight, colors["wall"])
+
+	// Draw start and goal as circles (matching Python version)
+	drawStartGoal(img, game, cellSize, titleHeight, colors["start"], colors["goal"])
+
+	// Draw exploration paths (matching Python version logic)
+	drawExplorationPaths(img, explorationRenderPaths(game), cellSize, titleHeight, game.Start, game.Goal)
+
+	// Encode to PNG
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderedPath is one exploration's line geometry plus its already-resolved
+// display color/width, decoupled from *Exploration so the same drawing code
+// in drawExplorationPaths serves both generateMazePNG's final-state render
+// and generateMazeGIF's partial, in-progress frames.
+type renderedPath struct {
+	Points    []Position
+	Current   Position
+	Active    bool
+	Color     color.RGBA
+	LineWidth int
+	// MarkerScale multiplies the current-position marker's size; zero means
+	// "use the normal size" (drawExplorationPaths treats it the same as
+	// 1.0), so GIF frames built without setting it are unaffected. Only
+	// explorationRenderPaths sets it below 1.0 or above, for an A*
+	// Pathfinder simulation's recorded FScore.
+	MarkerScale float64
+}
+
+// explorationRenderPaths converts every live Exploration into a
+// renderedPath, resolving each one's color/style via
+// getExplorationDisplayColorAndStyle exactly as generateMazePNG always has.
+func explorationRenderPaths(game *Game) []renderedPath {
+	paths := make([]renderedPath, 0, len(game.Explorations))
+	for _, exp := range game.Explorations {
+		pathColor, lineWidth, _, _ := game.getExplorationDisplayColorAndStyle(exp)
+		paths = append(paths, renderedPath{
+			Points:      exp.PathPositions,
+			Current:     exp.CurrentPosition,
+			Active:      exp.IsActive,
+			Color:       pathColor,
+			LineWidth:   lineWidth,
+			MarkerScale: markerScaleForFScore(exp.FScore),
+		})
+	}
+	return paths
+}
+
+// markerScaleForFScore turns an A* frontier node's f-score into a marker
+// size multiplier, so a viewer can see at a glance which nodes the
+// heuristic judged most promising: lower f (closer to the goal) draws
+// larger. Every non-A* exploration has a nil FScore and draws at the
+// normal size.
+func markerScaleForFScore(fScore *float64) float64 {
+	if fScore == nil {
+		return 1.0
+	}
+	scale := 1.6 - 0.08*(*fScore)
+	if scale < 0.5 {
+		scale = 0.5
+	}
+	if scale > 1.8 {
+		scale = 1.8
+	}
+	return scale
+}
+
+// drawMazeWalls fills every WALL cell of game.Maze onto img, offset by
+// yOffset pixels to leave room for a title bar (0 if there isn't one).
+func drawMazeWalls(img *image.RGBA, game *Game, cellSize, yOffset int, wallColor color.RGBA) {
 	for y := 0; y < game.Height; y++ {
 		for x := 0; x < game.Width; x++ {
-			cellType := game.Maze[y][x]
-			
-			// Only draw walls and special cells, paths use maze_bg
-			if cellType == WALL {
-				// Fill wall cell
-				for py := y*cellSize + titleHeight; py < (y+1)*cellSize+titleHeight; py++ {
-					for px := x * cellSize; px < (x+1)*cellSize; px++ {
-						img.Set(px, py, colors["wall"])
-					}
+			if game.Maze[y][x] != WALL {
+				continue
+			}
+			for py := y*cellSize + yOffset; py < (y+1)*cellSize+yOffset; py++ {
+				for px := x * cellSize; px < (x+1)*cellSize; px++ {
+					img.Set(px, py, wallColor)
 				}
 			}
 		}
 	}
-	
-	// Draw start and goal as circles (matching Python version)
-	start := game.Start
-	startCenterX := start.X*cellSize + cellSize/2
-	startCenterY := start.Y*cellSize + cellSize/2 + titleHeight
-	startRadius := int(float64(cellSize) * 0.35) // radius 0.35 like Python
-	drawCircleWithBorder(img, startCenterX, startCenterY, startRadius, 
-		colors["start"], color.RGBA{255, 255, 255, 255}, 2)
-	
-	goal := game.Goal
-	goalCenterX := goal.X*cellSize + cellSize/2
-	goalCenterY := goal.Y*cellSize + cellSize/2 + titleHeight
-	goalRadius := int(float64(cellSize) * 0.35) // radius 0.35 like Python
-	drawCircleWithBorder(img, goalCenterX, goalCenterY, goalRadius, 
-		colors["goal"], color.RGBA{255, 255, 255, 255}, 2)
+}
 
-	// Draw exploration paths (matching Python version logic)
-	for _, exp := range game.Explorations {
-		if len(exp.PathPositions) < 2 {
+// drawStartGoal draws the start/goal circle markers (matching Python
+// version: radius 0.35 of a cell, white 2px border).
+func drawStartGoal(img *image.RGBA, game *Game, cellSize, yOffset int, startColor, goalColor color.RGBA) {
+	white := color.RGBA{255, 255, 255, 255}
+	radius := int(float64(cellSize) * 0.35)
+
+	sx := game.Start.X*cellSize + cellSize/2
+	sy := game.Start.Y*cellSize + cellSize/2 + yOffset
+	drawCircleWithBorder(img, sx, sy, radius, startColor, white, 2)
+
+	gx := game.Goal.X*cellSize + cellSize/2
+	gy := game.Goal.Y*cellSize + cellSize/2 + yOffset
+	drawCircleWithBorder(img, gx, gy, radius, goalColor, white, 2)
+}
+
+// drawExplorationPaths renders each path's line and, for an active one not
+// currently sitting on start/goal, its robot marker (matching Python
+// version: radius 0.3/0.15 diamond with a white border and inner
+// highlight).
+func drawExplorationPaths(img *image.RGBA, paths []renderedPath, cellSize, yOffset int, start, goal Position) {
+	for _, p := range paths {
+		if len(p.Points) >= 2 {
+			for i := 1; i < len(p.Points); i++ {
+				x1 := p.Points[i-1].X*cellSize + cellSize/2
+				y1 := p.Points[i-1].Y*cellSize + cellSize/2 + yOffset
+				x2 := p.Points[i].X*cellSize + cellSize/2
+				y2 := p.Points[i].Y*cellSize + cellSize/2 + yOffset
+				drawLineRound(img, x1, y1, x2, y2, p.Color, p.LineWidth)
+			}
+		}
+
+		if !p.Active || p.Current == start || p.Current == goal {
 			continue
 		}
 
-		// Use complex parent-child color logic (matching Python version exactly)
-		pathColor, lineWidth, _, _ := game.getExplorationDisplayColorAndStyle(exp)
+		markerScale := p.MarkerScale
+		if markerScale == 0 {
+			markerScale = 1.0
+		}
+		centerX := p.Current.X*cellSize + cellSize/2
+		centerY := p.Current.Y*cellSize + cellSize/2 + yOffset
+		outerSize := int(float64(cellSize) * 0.3 * markerScale)
+		innerSize := int(float64(cellSize) * 0.15 * markerScale)
+		drawDiamondWithBorder(img, centerX, centerY, outerSize, p.Color, color.RGBA{255, 255, 255, 255}, 3)
+		drawDiamond(img, centerX, centerY, innerSize, color.RGBA{255, 255, 255, 160})
+	}
+}
 
-		// Draw path with proper line caps (matching Python's round caps)
-		for i := 1; i < len(exp.PathPositions); i++ {
-			prev := exp.PathPositions[i-1]
-			curr := exp.PathPositions[i]
-			
-			x1 := prev.X*cellSize + cellSize/2
-			y1 := prev.Y*cellSize + cellSize/2 + titleHeight
-			x2 := curr.X*cellSize + cellSize/2
-			y2 := curr.Y*cellSize + cellSize/2 + titleHeight
+// maxGIFFrames caps how many in-progress frames generateMazeGIF emits
+// before the final held frame, so a long run still produces a reasonably
+// sized file instead of one frame per move.
+const maxGIFFrames = 120
+
+// goalHoldMs is how long generateMazeGIF holds its final frame, so a viewer
+// has time to register the outcome before the animation loops.
+const goalHoldMs = 2000
+
+// generateMazeGIF renders the full exploration run as a palettized
+// image/gif animation: it replays game.MoveLog to recover each
+// exploration's PathPositions length at every tick (MoveLog already is that
+// history - one entry per accepted move), spaces frames evenly across it up
+// to maxGIFFrames, and appends a final frame of the true end state held for
+// goalHoldMs. frameIntervalMs sets every in-progress frame's display
+// duration in milliseconds (default 200).
+func generateMazeGIF(game *Game, frameIntervalMs int) ([]byte, error) {
+	if frameIntervalMs <= 0 {
+		frameIntervalMs = 200
+	}
+
+	cellSize := 20
+	width := game.Width * cellSize
+	height := game.Height * cellSize
+
+	type style struct {
+		Color     color.RGBA
+		LineWidth int
+	}
+	styleOf := make(map[string]style, len(game.Explorations))
+	for id, exp := range game.Explorations {
+		c, lw, _, _ := game.getExplorationDisplayColorAndStyle(exp)
+		styleOf[id] = style{Color: c, LineWidth: lw}
+	}
+
+	type liveExploration struct {
+		Points  []Position
+		Current Position
+		Active  bool
+	}
+	live := make(map[string]*liveExploration)
+	tickPaths := make([][]renderedPath, 0, len(game.MoveLog))
 
-			// Use round line caps and joins like Python version
-			drawLineRound(img, x1, y1, x2, y2, pathColor, lineWidth)
+	for _, entry := range game.MoveLog {
+		le, exists := live[entry.ExplorationName]
+		if !exists {
+			le = &liveExploration{Points: []Position{entry.To}, Current: entry.To, Active: true}
+			live[entry.ExplorationName] = le
+		} else {
+			le.Points = append(le.Points, entry.To)
+			le.Current = entry.To
+		}
+		if entry.NewStatus == "goal_reached" || entry.NewStatus == "dead_end" {
+			le.Active = false
 		}
 
-		// Draw robot marker for active explorations (matching Python version)
-		if exp.IsActive {
-			pos := exp.CurrentPosition
-			// Skip if at start/goal positions (already drawn with special markers)
-			if !((pos.X == game.Start.X && pos.Y == game.Start.Y) ||
-				(pos.X == game.Goal.X && pos.Y == game.Goal.Y)) {
-				
-				centerX := pos.X*cellSize + cellSize/2
-				centerY := pos.Y*cellSize + cellSize/2 + titleHeight
-				
-				// Match Python version: radius=0.3 of cell, white border, inner highlight
-				outerSize := int(float64(cellSize) * 0.3)  // radius 0.3
-				innerSize := int(float64(cellSize) * 0.15) // radius 0.15
-
-				// Get explorer color using complex parent-child logic (matching Python version)
-				explorerColor, _, _, _ := game.getExplorationDisplayColorAndStyle(exp)
-				
-				// Draw outer diamond with white border (3px border)
-				drawDiamondWithBorder(img, centerX, centerY, outerSize, explorerColor, 
-					color.RGBA{255, 255, 255, 255}, 3)
-				
-				// Draw inner white highlight
-				drawDiamond(img, centerX, centerY, innerSize, 
-					color.RGBA{255, 255, 255, 160}) // Semi-transparent white
-			}
+		tick := make([]renderedPath, 0, len(live))
+		for id, l := range live {
+			s := styleOf[id]
+			tick = append(tick, renderedPath{
+				Points:    l.Points,
+				Current:   l.Current,
+				Active:    l.Active,
+				Color:     s.Color,
+				LineWidth: s.LineWidth,
+			})
 		}
+		tickPaths = append(tickPaths, tick)
 	}
 
-	// Encode to PNG
+	if len(tickPaths) == 0 {
+		tickPaths = append(tickPaths, nil)
+	}
+
+	frameStep := 1
+	if len(tickPaths) > maxGIFFrames {
+		frameStep = (len(tickPaths) + maxGIFFrames - 1) / maxGIFFrames
+	}
+
+	anim := &gif.GIF{}
+	delay := frameIntervalMs / 10 // GIF delay is in 1/100ths of a second
+	if delay <= 0 {
+		delay = 1
+	}
+
+	for i := 0; i < len(tickPaths); i += frameStep {
+		frame, err := renderMazeFrame(game, tickPaths[i], cellSize, width, height)
+		if err != nil {
+			return nil, err
+		}
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	finalFrame, err := renderMazeFrame(game, explorationRenderPaths(game), cellSize, width, height)
+	if err != nil {
+		return nil, err
+	}
+	anim.Image = append(anim.Image, finalFrame)
+	anim.Delay = append(anim.Delay, goalHoldMs/10)
+
 	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
+	if err := gif.EncodeAll(&buf, anim); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
+// renderMazeFrame draws the maze, start/goal, and paths onto a freshly
+// palettized frame for use in generateMazeGIF's animation - no title bar,
+// since per-tick stats aren't meaningful mid-replay.
+func renderMazeFrame(game *Game, paths []renderedPath, cellSize, width, height int) (*image.Paletted, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{250, 250, 250, 255}}, image.ZP, draw.Src)
+
+	drawMazeWalls(img, game, cellSize, 0, color.RGBA{224, 224, 224, 255})
+	drawStartGoal(img, game, cellSize, 0, color.RGBA{76, 175, 80, 255}, color.RGBA{244, 67, 54, 255})
+	drawExplorationPaths(img, paths, cellSize, 0, game.Start, game.Goal)
+
+	paletted := image.NewPaletted(img.Bounds(), palette.WebSafe)
+	draw.FloydSteinberg.Draw(paletted, img.Bounds(), img, image.ZP)
+	return paletted, nil
+}
+
 // Helper function to draw title
-func drawTitle(img *image.RGBA, width, height int) {
+func drawTitle(img *image.RGBA, game *Game, width, height int) {
 	// Get current statistics
 	stats := game.getExplorationTree()
-	
+
 	// Colors matching Python version exactly
-	bgColor := color.RGBA{255, 255, 255, 255}     // White background
-	textColor := color.RGBA{66, 66, 66, 255}     // Dark gray text
-	winnerColor := color.RGBA{255, 109, 0, 255}  // Gold for winner
-	
+	bgColor := color.RGBA{255, 255, 255, 255}   // White background
+	textColor := color.RGBA{66, 66, 66, 255}    // Dark gray text
+	winnerColor := color.RGBA{255, 109, 0, 255} // Gold for winner
+
 	// Clear title area with white background
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
 			img.Set(x, y, bgColor)
 		}
 	}
-	
+
+	const titleTop = 6
+	const subtitleTop = 32
+
 	// Draw title text with proper font rendering
 	if stats.GlobalStats.GoalFound {
 		// Match Python version title when goal found
 		titleText := "PANTHEON MAZE SOLVED!"
-		drawBetterText(img, titleText, width/2, 15, winnerColor, true) // Centered, bold
-		
-		subtitleText := fmt.Sprintf("Multi-Branch BFS Pathfinding | Winner: root | Segments: %d", 
+		drawBetterText(img, titleText, width/2, titleTop, TextStyle{Size: 18, Bold: true, Color: winnerColor})
+
+		subtitleText := fmt.Sprintf("Multi-Branch BFS Pathfinding | Winner: root | Segments: %d",
 			stats.GlobalStats.TotalExplorations)
-		drawBetterText(img, subtitleText, width/2, 35, textColor, false) // Centered, normal
+		drawBetterText(img, subtitleText, width/2, subtitleTop, TextStyle{Size: 12, Color: textColor})
 	} else {
 		// Match Python version title during exploration
 		titleText := "Multi-Branch BFS Pathfinding"
-		drawBetterText(img, titleText, width/2, 15, textColor, true) // Centered, bold
-		
-		subtitleText := fmt.Sprintf("Concurrent exploration spawning branches at junctions | Active: %d | Total: %d", 
+		drawBetterText(img, titleText, width/2, titleTop, TextStyle{Size: 18, Bold: true, Color: textColor})
+
+		subtitleText := fmt.Sprintf("Concurrent exploration spawning branches at junctions | Active: %d | Total: %d",
 			stats.GlobalStats.ActiveExplorations,
 			stats.GlobalStats.TotalExplorations)
-		drawBetterText(img, subtitleText, width/2, 35, textColor, false) // Centered, normal
+		drawBetterText(img, subtitleText, width/2, subtitleTop, TextStyle{Size: 12, Color: textColor})
 	}
 }
 
-// Helper function to draw better text (centered) using official font library
-func drawBetterText(img *image.RGBA, text string, centerX, y int, textColor color.RGBA, bold bool) {
-	// Use official Go font library - supports full ASCII character set
-	fontFace := basicfont.Face7x13
-	if bold {
-		// Use a larger font for bold effect
-		fontFace = basicfont.Face7x13
+// TextStyle describes how drawBetterText should render a string: point
+// size, weight/slant, and fill color. (Size, Bold, Italic) is also the
+// cache key fontFaceFor uses, so the same style always reuses one
+// rasterized font.Face instead of re-rendering its glyphs per draw.
+type TextStyle struct {
+	Size   float64
+	Bold   bool
+	Italic bool
+	Color  color.RGBA
+}
+
+// fontFaceKey is the cacheable subset of TextStyle - Color doesn't affect
+// which glyphs get rasterized, so it's left out of the key.
+type fontFaceKey struct {
+	Size   float64
+	Bold   bool
+	Italic bool
+}
+
+var (
+	regularFont    = mustParseFont(goregular.TTF)
+	boldFont       = mustParseFont(gobold.TTF)
+	italicFont     = mustParseFont(goitalic.TTF)
+	boldItalicFont = mustParseFont(gobolditalic.TTF)
+
+	fontFacesMu sync.Mutex
+	fontFaces   = make(map[fontFaceKey]font.Face)
+)
+
+// mustParseFont parses one of the embedded Go font TTFs bundled by
+// golang.org/x/image/font/gofont; a failure here means the dependency
+// itself is broken, so it panics at init instead of at first draw.
+func mustParseFont(data []byte) *opentype.Font {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("parse embedded font: %v", err))
 	}
-	
+	return f
+}
+
+// fontFaceFor returns the cached font.Face for style's (size, weight, slant)
+// combination, rasterizing and caching it on first use.
+func fontFaceFor(style TextStyle) font.Face {
+	key := fontFaceKey{Size: style.Size, Bold: style.Bold, Italic: style.Italic}
+
+	fontFacesMu.Lock()
+	defer fontFacesMu.Unlock()
+	if face, ok := fontFaces[key]; ok {
+		return face
+	}
+
+	src := regularFont
+	switch {
+	case style.Bold && style.Italic:
+		src = boldItalicFont
+	case style.Bold:
+		src = boldFont
+	case style.Italic:
+		src = italicFont
+	}
+
+	face, err := opentype.NewFace(src, &opentype.FaceOptions{
+		Size:    style.Size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("build font face for %+v: %v", style, err))
+	}
+
+	fontFaces[key] = face
+	return face
+}
+
+// drawBetterText draws text horizontally centered on centerX, with its em
+// box's top edge at top, using the cached font.Face for style - see
+// fontFaceFor.
+func drawBetterText(img *image.RGBA, text string, centerX, top int, style TextStyle) {
+	face := fontFaceFor(style)
+
 	drawer := &font.Drawer{
 		Dst:  img,
-		Src:  image.NewUniform(textColor),
-		Face: fontFace,
+		Src:  image.NewUniform(style.Color),
+		Face: face,
 	}
-	
+
 	// Measure text width for centering
 	textWidth := drawer.MeasureString(text)
 	textWidthPixels := int(textWidth >> 6) // Convert fixed.Int26_6 to pixels
 	startX := centerX - textWidthPixels/2
-	
-	// Set drawing position
+
+	baseline := top + face.Metrics().Ascent.Ceil()
 	drawer.Dot = fixed.Point26_6{
 		X: fixed.I(startX),
-		Y: fixed.I(y + 12), // Adjust baseline position
+		Y: fixed.I(baseline),
 	}
-	
-	// Draw the text
+
 	drawer.DrawString(text)
 }
 
@@ -979,155 +3585,69 @@ func min(a, b int) int {
 	return b
 }
 
-// Helper function to draw line (basic version)
-func drawLine(img *image.RGBA, x0, y0, x1, y1 int, color color.RGBA, width int) {
-	dx := abs(x1 - x0)
-	dy := abs(y1 - y0)
-	sx, sy := 1, 1
-	if x0 > x1 {
-		sx = -1
-	}
-	if y0 > y1 {
-		sy = -1
-	}
-	err := dx - dy
-
-	x, y := x0, y0
-	for {
-		// Draw thick line by drawing multiple pixels
-		for i := -width/2; i <= width/2; i++ {
-			for j := -width/2; j <= width/2; j++ {
-				if x+i >= 0 && y+j >= 0 && x+i < img.Bounds().Max.X && y+j < img.Bounds().Max.Y {
-					img.Set(x+i, y+j, color)
-				}
-			}
-		}
-
-		if x == x1 && y == y1 {
-			break
-		}
-		e2 := 2 * err
-		if e2 > -dy {
-			err -= dy
-			x += sx
-		}
-		if e2 < dx {
-			err += dx
-			y += sy
-		}
-	}
+// newGraphicContext builds a draw2d context over img. Callers set their own
+// fill/stroke colors and widths before drawing a path with it.
+func newGraphicContext(img *image.RGBA) *draw2dimg.GraphicContext {
+	return draw2dimg.NewGraphicContext(img)
 }
 
-// Helper function to draw line with round caps (matching Python version)
-func drawLineRound(img *image.RGBA, x0, y0, x1, y1 int, color color.RGBA, width int) {
-	// Draw the main line
-	drawLine(img, x0, y0, x1, y1, color, width)
-	
-	// Add round caps at both ends
-	radius := width / 2
-	if radius < 1 {
-		radius = 1
-	}
-	
-	// Draw round cap at start
-	for dy := -radius; dy <= radius; dy++ {
-		for dx := -radius; dx <= radius; dx++ {
-			if dx*dx+dy*dy <= radius*radius {
-				x := x0 + dx
-				y := y0 + dy
-				if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-					img.Set(x, y, color)
-				}
-			}
-		}
-	}
-	
-	// Draw round cap at end
-	for dy := -radius; dy <= radius; dy++ {
-		for dx := -radius; dx <= radius; dx++ {
-			if dx*dx+dy*dy <= radius*radius {
-				x := x1 + dx
-				y := y1 + dy
-				if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-					img.Set(x, y, color)
-				}
-			}
-		}
-	}
+// drawLineRound strokes a line with round caps and joins via draw2d, so it
+// matches the SVG renderer's "round caps" line style with real
+// anti-aliasing instead of a manually rasterized circle at each endpoint.
+func drawLineRound(img *image.RGBA, x0, y0, x1, y1 int, lineColor color.RGBA, width int) {
+	gc := newGraphicContext(img)
+	gc.SetStrokeColor(lineColor)
+	gc.SetLineWidth(float64(width))
+	gc.SetLineCap(draw2d.RoundCap)
+	gc.SetLineJoin(draw2d.RoundJoin)
+	gc.BeginPath()
+	gc.MoveTo(float64(x0), float64(y0))
+	gc.LineTo(float64(x1), float64(y1))
+	gc.Stroke()
 }
 
-// Helper function to draw circle with border (matching Python version)
+// drawCircleWithBorder draws an anti-aliased filled circle with a stroked
+// border, used for the start/goal markers.
 func drawCircleWithBorder(img *image.RGBA, centerX, centerY, radius int, fillColor, borderColor color.RGBA, borderWidth int) {
-	// Draw border first (larger circle)
-	outerRadius := radius + borderWidth
-	for dy := -outerRadius; dy <= outerRadius; dy++ {
-		for dx := -outerRadius; dx <= outerRadius; dx++ {
-			if dx*dx+dy*dy <= outerRadius*outerRadius {
-				x := centerX + dx
-				y := centerY + dy
-				if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-					img.Set(x, y, borderColor)
-				}
-			}
-		}
-	}
-	
-	// Draw fill (inner circle)
-	for dy := -radius; dy <= radius; dy++ {
-		for dx := -radius; dx <= radius; dx++ {
-			if dx*dx+dy*dy <= radius*radius {
-				x := centerX + dx
-				y := centerY + dy
-				if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-					img.Set(x, y, fillColor)
-				}
-			}
-		}
-	}
+	gc := newGraphicContext(img)
+	gc.SetFillColor(fillColor)
+	gc.SetStrokeColor(borderColor)
+	gc.SetLineWidth(float64(borderWidth))
+	draw2dkit.Circle(gc, float64(centerX), float64(centerY), float64(radius))
+	gc.FillStroke()
 }
 
-// Helper function to draw diamond (basic version)
-func drawDiamond(img *image.RGBA, centerX, centerY, size int, color color.RGBA) {
-	for dy := -size; dy <= size; dy++ {
-		width := size - abs(dy)
-		for dx := -width; dx <= width; dx++ {
-			x := centerX + dx
-			y := centerY + dy
-			if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-				img.Set(x, y, color)
-			}
-		}
-	}
+// diamondPath traces the four-sided robot-marker diamond centered on
+// (centerX, centerY) onto gc's current path.
+func diamondPath(gc *draw2dimg.GraphicContext, centerX, centerY, size int) {
+	gc.BeginPath()
+	gc.MoveTo(float64(centerX), float64(centerY-size))
+	gc.LineTo(float64(centerX+size), float64(centerY))
+	gc.LineTo(float64(centerX), float64(centerY+size))
+	gc.LineTo(float64(centerX-size), float64(centerY))
+	gc.Close()
+}
+
+// drawDiamond fills a diamond with no border, used for the robot marker's
+// inner highlight.
+func drawDiamond(img *image.RGBA, centerX, centerY, size int, fillColor color.RGBA) {
+	gc := newGraphicContext(img)
+	gc.SetFillColor(fillColor)
+	diamondPath(gc, centerX, centerY, size)
+	gc.Fill()
 }
 
-// Helper function to draw diamond with border (matching Python version)
+// drawDiamondWithBorder fills and strokes the robot marker diamond.
 func drawDiamondWithBorder(img *image.RGBA, centerX, centerY, size int, fillColor, borderColor color.RGBA, borderWidth int) {
-	// Draw border first (larger diamond)
-	for dy := -(size + borderWidth); dy <= (size + borderWidth); dy++ {
-		width := (size + borderWidth) - abs(dy)
-		for dx := -width; dx <= width; dx++ {
-			x := centerX + dx
-			y := centerY + dy
-			if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-				img.Set(x, y, borderColor)
-			}
-		}
-	}
-	
-	// Draw fill (inner diamond)
-	for dy := -size; dy <= size; dy++ {
-		width := size - abs(dy)
-		for dx := -width; dx <= width; dx++ {
-			x := centerX + dx
-			y := centerY + dy
-			if x >= 0 && y >= 0 && x < img.Bounds().Max.X && y < img.Bounds().Max.Y {
-				img.Set(x, y, fillColor)
-			}
-		}
-	}
+	gc := newGraphicContext(img)
+	gc.SetFillColor(fillColor)
+	gc.SetStrokeColor(borderColor)
+	gc.SetLineWidth(float64(borderWidth))
+	diamondPath(gc, centerX, centerY, size)
+	gc.FillStroke()
 }
 
-func generateMazeSVG() string {
+func generateMazeSVG(game *Game) string {
 	cellSize := 20
 	width := game.Width * cellSize
 	height := game.Height * cellSize
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// walkFrom flood-fills every cell reachable from start by single steps
+// across non-WALL cells, the same adjacency getValidDirections uses.
+func walkFrom(maze [][]CellType, width, height int, start Position) map[Position]bool {
+	visited := map[Position]bool{start: true}
+	queue := []Position{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, dir := range []Direction{UP, DOWN, LEFT, RIGHT} {
+			next := cur.Add(dir)
+			if next.X < 0 || next.X >= width || next.Y < 0 || next.Y >= height {
+				continue
+			}
+			if maze[next.Y][next.X] == WALL || visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return visited
+}
+
+func TestMazeGeneratorsProduceConnectedMazes(t *testing.T) {
+	const width, height = 15, 15
+
+	for name, gen := range mazeGenerators {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			maze, start, goal := gen.Generate(width, height, rng)
+
+			if len(maze) != height {
+				t.Fatalf("Generate() produced %d rows, want %d", len(maze), height)
+			}
+			for _, row := range maze {
+				if len(row) != width {
+					t.Fatalf("Generate() produced a row of %d cells, want %d", len(row), width)
+				}
+			}
+
+			if maze[start.Y][start.X] != START {
+				t.Errorf("start cell %v is %v, want START", start, maze[start.Y][start.X])
+			}
+			if maze[goal.Y][goal.X] != GOAL {
+				t.Errorf("goal cell %v is %v, want GOAL", goal, maze[goal.Y][goal.X])
+			}
+
+			reachable := walkFrom(maze, width, height, start)
+			if !reachable[goal] {
+				t.Errorf("goal %v is unreachable from start %v", goal, start)
+			}
+
+			for y := 1; y < height-1; y += 2 {
+				for x := 1; x < width-1; x += 2 {
+					cell := Position{x, y}
+					if maze[y][x] != WALL && !reachable[cell] {
+						t.Errorf("cell %v (%v) is unreachable from start %v", cell, maze[y][x], start)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestResolveMazeGeneratorFallsBackOnUnknownName(t *testing.T) {
+	gen, resolved := resolveMazeGenerator("not-a-real-algorithm")
+	if resolved != defaultMazeAlgorithm {
+		t.Errorf("resolveMazeGenerator() resolved = %q, want default %q", resolved, defaultMazeAlgorithm)
+	}
+	if gen != mazeGenerators[defaultMazeAlgorithm] {
+		t.Errorf("resolveMazeGenerator() returned a generator other than the default")
+	}
+
+	for name, want := range mazeGenerators {
+		gen, resolved := resolveMazeGenerator(name)
+		if resolved != name {
+			t.Errorf("resolveMazeGenerator(%q) resolved = %q, want %q", name, resolved, name)
+		}
+		if gen != want {
+			t.Errorf("resolveMazeGenerator(%q) returned the wrong generator", name)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import "testing"
+
+func TestPathfindersReachTheGoal(t *testing.T) {
+	for name := range pathfinders {
+		t.Run(name, func(t *testing.T) {
+			origin := NewGame(15, 15, 1, defaultMazeAlgorithm, GoalSingle)
+
+			sim, resolved := runPathfindingSimulation(origin, name)
+			if resolved != name {
+				t.Fatalf("runPathfindingSimulation() resolved = %q, want %q", resolved, name)
+			}
+			if !sim.GoalFound {
+				t.Fatalf("GoalFound = false after simulating %q to completion", name)
+			}
+			if sim.WinningExploration == nil {
+				t.Fatalf("WinningExploration is nil after %q found the goal", name)
+			}
+			if _, ok := sim.Explorations[*sim.WinningExploration]; !ok {
+				t.Fatalf("WinningExploration %q has no matching Exploration", *sim.WinningExploration)
+			}
+		})
+	}
+}
+
+// collectGoalModePathfinders excludes bidirectional: it declares a win the
+// moment its two trees meet, independent of checkWinCondition, so it never
+// actually exercises the item-visitation path this test is for.
+func collectGoalModePathfinders() []string {
+	var names []string
+	for name := range pathfinders {
+		if name != "bidirectional" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// newCorridorGame builds a single-wide, wall-bounded corridor from (0,1) to
+// (length-1,1) with items at itemXs - a maze with exactly one possible path,
+// so whichever Pathfinder reaches the goal is guaranteed to have passed
+// through every item on the way, independent of search-order details.
+func newCorridorGame(length int, itemXs []int, mode GoalMode) *Game {
+	const height = 3
+	maze := make([][]CellType, height)
+	for y := range maze {
+		maze[y] = make([]CellType, length)
+		if y == 1 {
+			for x := range maze[y] {
+				maze[y][x] = PATH
+			}
+		}
+	}
+
+	start := Position{0, 1}
+	goal := Position{length - 1, 1}
+	maze[start.Y][start.X] = START
+	maze[goal.Y][goal.X] = GOAL
+
+	items := make([]Position, len(itemXs))
+	for i, x := range itemXs {
+		items[i] = Position{x, 1}
+		maze[1][x] = ITEM
+	}
+
+	return &Game{
+		Maze:     maze,
+		Width:    length,
+		Height:   height,
+		Start:    start,
+		Goal:     goal,
+		GoalMode: mode,
+		Goals:    []Position{goal},
+		Items:    items,
+	}
+}
+
+// TestPathfindersSatisfyCollectAll exercises the bug this request fixed:
+// every Pathfinder used to spawn each frontier node as a fresh single-cell
+// Exploration, so checkWinCondition's GoalCollectAll could never see an item
+// visited earlier in the same search branch, only the current cell.
+func TestPathfindersSatisfyCollectAll(t *testing.T) {
+	for _, name := range collectGoalModePathfinders() {
+		t.Run(name, func(t *testing.T) {
+			origin := newCorridorGame(9, []int{2, 4, 6}, GoalCollectAll)
+
+			sim, _ := runPathfindingSimulation(origin, name)
+			if !sim.GoalFound {
+				t.Fatalf("GoalFound = false simulating %q along a corridor through every item", name)
+			}
+
+			winner := sim.Explorations[*sim.WinningExploration]
+			if !pathVisitsAll(winner.PathPositions, sim.Items) {
+				t.Errorf("winning exploration's PathPositions %v didn't visit every item in %v", winner.PathPositions, sim.Items)
+			}
+		})
+	}
+}
+
+func TestResolvePathfinderFallsBackOnUnknownName(t *testing.T) {
+	pf, resolved := resolvePathfinder("not-a-real-algorithm")
+	if resolved != defaultPathfindingAlgorithm {
+		t.Errorf("resolvePathfinder() resolved = %q, want default %q", resolved, defaultPathfindingAlgorithm)
+	}
+	if pf.Name() != defaultPathfindingAlgorithm {
+		t.Errorf("resolvePathfinder() returned a %q Pathfinder, want %q", pf.Name(), defaultPathfindingAlgorithm)
+	}
+}
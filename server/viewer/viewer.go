@@ -0,0 +1,279 @@
+//go:build viewer
+
+// Package viewer renders a live maze exploration in an interactive Ebiten
+// window: pan/zoom over the maze, pause/step through snapshot updates, and
+// click any cell to spawn a new exploration there.
+//
+// It never touches game state directly - the host process (the maze server)
+// owns the Game and exposes it only through the read-only Source interface
+// below, so the color/style rules in getExplorationDisplayColorAndStyle and
+// the spawn logic in moveExploration are reused as-is rather than
+// reimplemented here.
+package viewer
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// CellKind mirrors the host's maze cell classification (wall, path, start,
+// goal, item) without depending on its package.
+type CellKind int
+
+const (
+	Wall CellKind = iota
+	Path
+	Start
+	Goal
+	Item
+)
+
+// Position is a maze grid coordinate.
+type Position struct {
+	X, Y int
+}
+
+// PathSegment is one exploration's current path and display style, already
+// resolved by the host's getExplorationDisplayColorAndStyle so this package
+// never has to guess at the parent/child coloring rules.
+type PathSegment struct {
+	ID        string
+	Points    []Position
+	Current   Position
+	IsActive  bool
+	Color     color.RGBA
+	LineWidth int
+}
+
+// Snapshot is a read-only copy of everything one frame needs to render.
+type Snapshot struct {
+	Width, Height int
+	Cells         [][]CellKind
+	Start, Goal   Position
+	Paths         []PathSegment
+	GoalFound     bool
+}
+
+// Source is the read-only API the viewer polls each frame, plus the one
+// write it needs for click-to-spawn. The host implements it against its own
+// Game; the viewer never sees Game itself.
+type Source interface {
+	Snapshot() Snapshot
+	SpawnExploration(at Position) bool
+}
+
+const (
+	cellSize    = 20
+	minZoom     = 0.25
+	maxZoom     = 4.0
+	panPerFrame = 8.0
+)
+
+var (
+	wallColor  = color.RGBA{224, 224, 224, 255}
+	bgColor    = color.RGBA{250, 250, 250, 255}
+	startColor = color.RGBA{76, 175, 80, 255}
+	goalColor  = color.RGBA{244, 67, 54, 255}
+	itemColor  = color.RGBA{255, 193, 7, 255}
+)
+
+// Viewer implements ebiten.Game over a Source, maintaining its own camera
+// and pause/step state; the Source itself is never mutated except through
+// SpawnExploration.
+type Viewer struct {
+	source Source
+
+	offsetX, offsetY float64
+	zoom             float64
+
+	paused   bool
+	doStep   bool
+	snapshot Snapshot
+
+	dragging     bool
+	dragStartX   int
+	dragStartY   int
+	dragOffsetX0 float64
+	dragOffsetY0 float64
+}
+
+// New builds a Viewer over source at the default zoom level, with the
+// maze's top-left corner at the window's origin.
+func New(source Source) *Viewer {
+	return &Viewer{
+		source:   source,
+		zoom:     1.0,
+		snapshot: source.Snapshot(),
+	}
+}
+
+// Run opens the window and blocks until it's closed, as ebiten.RunGame
+// requires it to run on the OS main thread.
+func Run(source Source) error {
+	ebiten.SetWindowTitle("Maze Exploration Viewer")
+	ebiten.SetWindowResizable(true)
+	ebiten.SetWindowSize(900, 700)
+	return ebiten.RunGame(New(source))
+}
+
+func (v *Viewer) Update() error {
+	v.handleZoom()
+	v.handlePan()
+	v.handleClick()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		v.paused = !v.paused
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) || inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+		v.doStep = true
+	}
+
+	if !v.paused || v.doStep {
+		v.snapshot = v.source.Snapshot()
+		v.doStep = false
+	}
+
+	return nil
+}
+
+func (v *Viewer) handleZoom() {
+	if _, dy := ebiten.Wheel(); dy != 0 {
+		v.zoom *= 1 + dy*0.1
+		if v.zoom < minZoom {
+			v.zoom = minZoom
+		}
+		if v.zoom > maxZoom {
+			v.zoom = maxZoom
+		}
+	}
+}
+
+func (v *Viewer) handlePan() {
+	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		v.offsetX += panPerFrame
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyRight) {
+		v.offsetX -= panPerFrame
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyUp) {
+		v.offsetY += panPerFrame
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyDown) {
+		v.offsetY -= panPerFrame
+	}
+
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+		x, y := ebiten.CursorPosition()
+		if !v.dragging {
+			v.dragging = true
+			v.dragStartX, v.dragStartY = x, y
+			v.dragOffsetX0, v.dragOffsetY0 = v.offsetX, v.offsetY
+		} else {
+			v.offsetX = v.dragOffsetX0 + float64(x-v.dragStartX)
+			v.offsetY = v.dragOffsetY0 + float64(y-v.dragStartY)
+		}
+	} else {
+		v.dragging = false
+	}
+}
+
+// handleClick spawns a new exploration at the cell under the cursor on a
+// left click. Panning uses the right button, so it never triggers a spawn.
+func (v *Viewer) handleClick() {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	sx, sy := ebiten.CursorPosition()
+	pos := v.screenToCell(sx, sy)
+	if pos.X < 0 || pos.Y < 0 || pos.X >= v.snapshot.Width || pos.Y >= v.snapshot.Height {
+		return
+	}
+	v.source.SpawnExploration(pos)
+}
+
+func (v *Viewer) screenToCell(sx, sy int) Position {
+	worldX := (float64(sx) - v.offsetX) / (cellSize * v.zoom)
+	worldY := (float64(sy) - v.offsetY) / (cellSize * v.zoom)
+	return Position{X: int(worldX), Y: int(worldY)}
+}
+
+func (v *Viewer) cellToScreen(p Position) (float64, float64) {
+	scale := cellSize * v.zoom
+	return v.offsetX + float64(p.X)*scale, v.offsetY + float64(p.Y)*scale
+}
+
+func (v *Viewer) Draw(screen *ebiten.Image) {
+	screen.Fill(bgColor)
+	scale := float32(cellSize * v.zoom)
+
+	for y, row := range v.snapshot.Cells {
+		for x, cell := range row {
+			if cell != Wall {
+				continue
+			}
+			px, py := v.cellToScreen(Position{X: x, Y: y})
+			vector.DrawFilledRect(screen, float32(px), float32(py), scale, scale, wallColor, false)
+		}
+	}
+
+	drawMarker(screen, v, v.snapshot.Start, startColor)
+	drawMarker(screen, v, v.snapshot.Goal, goalColor)
+	for _, item := range itemsOrEmpty(v.snapshot) {
+		drawMarker(screen, v, item, itemColor)
+	}
+
+	for _, seg := range v.snapshot.Paths {
+		v.drawPath(screen, seg)
+	}
+
+	status := "running"
+	if v.paused {
+		status = "paused (S/-> to step)"
+	}
+	ebitenutil.DebugPrint(screen, fmt.Sprintf(
+		"explorations: %d | goal found: %v | %s\nspace: pause  drag RMB: pan  wheel: zoom  click: spawn",
+		len(v.snapshot.Paths), v.snapshot.GoalFound, status))
+}
+
+func itemsOrEmpty(s Snapshot) []Position {
+	var items []Position
+	for y, row := range s.Cells {
+		for x, cell := range row {
+			if cell == Item {
+				items = append(items, Position{X: x, Y: y})
+			}
+		}
+	}
+	return items
+}
+
+func drawMarker(screen *ebiten.Image, v *Viewer, p Position, col color.RGBA) {
+	scale := float32(cellSize * v.zoom)
+	px, py := v.cellToScreen(p)
+	vector.DrawFilledCircle(screen, float32(px)+scale/2, float32(py)+scale/2, scale*0.35, col, true)
+}
+
+func (v *Viewer) drawPath(screen *ebiten.Image, seg PathSegment) {
+	lineWidth := float32(seg.LineWidth)
+	if lineWidth <= 0 {
+		lineWidth = 2
+	}
+	for i := 1; i < len(seg.Points); i++ {
+		x0, y0 := v.cellToScreen(seg.Points[i-1])
+		x1, y1 := v.cellToScreen(seg.Points[i])
+		scale := float32(cellSize * v.zoom)
+		vector.StrokeLine(screen, x0+scale/2, y0+scale/2, x1+scale/2, y1+scale/2, lineWidth, seg.Color, true)
+	}
+	if seg.IsActive {
+		drawMarker(screen, v, seg.Current, seg.Color)
+	}
+}
+
+func (v *Viewer) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
@@ -0,0 +1,112 @@
+//go:build viewer
+
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/IANTHEREAL/maze/server/viewer"
+)
+
+// launchViewer opens the interactive Ebiten window onto game. Building with
+// this file requires cgo plus the platform's GL/X11 dev headers - pass
+// `-tags viewer` explicitly to opt in; the plain `go build ./server/...` the
+// headless HTTP server ships with instead links the launchViewer stub in
+// viewer_stub.go, which only returns an error.
+func launchViewer(g *Game) error {
+	return viewer.Run(g)
+}
+
+// viewerSpawnCounter names each exploration the interactive viewer spawns by
+// click, independent of the REST API's caller-supplied exploration names.
+var viewerSpawnCounter int64
+
+// Snapshot implements viewer.Source, giving the viewer package a read-only
+// copy of the maze and exploration state. It reuses
+// getExplorationDisplayColorAndStyle so the viewer's colors never drift from
+// the PNG/SVG renderers.
+func (g *Game) Snapshot() viewer.Snapshot {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	cells := make([][]viewer.CellKind, g.Height)
+	for y := 0; y < g.Height; y++ {
+		cells[y] = make([]viewer.CellKind, g.Width)
+		for x := 0; x < g.Width; x++ {
+			cells[y][x] = viewerCellKind(g.Maze[y][x])
+		}
+	}
+
+	paths := make([]viewer.PathSegment, 0, len(g.Explorations))
+	for _, exp := range g.Explorations {
+		col, lineWidth, _, _ := g.getExplorationDisplayColorAndStyle(exp)
+		paths = append(paths, viewer.PathSegment{
+			ID:        exp.ID,
+			Points:    viewerPositions(exp.PathPositions),
+			Current:   viewerPosition(exp.CurrentPosition),
+			IsActive:  exp.IsActive,
+			Color:     col,
+			LineWidth: lineWidth,
+		})
+	}
+
+	return viewer.Snapshot{
+		Width:     g.Width,
+		Height:    g.Height,
+		Cells:     cells,
+		Start:     viewerPosition(g.Start),
+		Goal:      viewerPosition(g.Goal),
+		Paths:     paths,
+		GoalFound: g.GoalFound,
+	}
+}
+
+// SpawnExploration implements viewer.Source's click-to-spawn hook. It starts
+// a brand-new exploration at at the same way handleMove does for a
+// never-seen exploration name, just generating the name itself since a mouse
+// click has no caller-supplied one.
+func (g *Game) SpawnExploration(at viewer.Position) bool {
+	pos := Position{X: at.X, Y: at.Y}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.isWalkable(pos) || g.isCollision(pos) {
+		return false
+	}
+
+	name := fmt.Sprintf("click.%d", atomic.AddInt64(&viewerSpawnCounter, 1))
+	response := g.moveExploration(name, pos)
+	if response.Success {
+		g.SnapshotVersion++
+	}
+	return response.Success
+}
+
+func viewerCellKind(c CellType) viewer.CellKind {
+	switch c {
+	case START:
+		return viewer.Start
+	case GOAL:
+		return viewer.Goal
+	case ITEM:
+		return viewer.Item
+	case PATH:
+		return viewer.Path
+	default:
+		return viewer.Wall
+	}
+}
+
+func viewerPosition(p Position) viewer.Position {
+	return viewer.Position{X: p.X, Y: p.Y}
+}
+
+func viewerPositions(ps []Position) []viewer.Position {
+	out := make([]viewer.Position, len(ps))
+	for i, p := range ps {
+		out[i] = viewerPosition(p)
+	}
+	return out
+}
@@ -0,0 +1,13 @@
+//go:build !viewer
+
+package main
+
+import "fmt"
+
+// launchViewer is the no-op stand-in for viewer_adapter.go's real
+// implementation when the binary is built without `-tags viewer` - the
+// default, since the Ebiten viewer needs cgo plus the platform's GL/X11 dev
+// headers that a headless server build (CI/Docker/prod) shouldn't require.
+func launchViewer(g *Game) error {
+	return fmt.Errorf("this binary was built without viewer support; rebuild with `-tags viewer` to use -viewer")
+}